@@ -0,0 +1,122 @@
+package decimal
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Allocate splits total into len(ratios) parts proportional to ratios and
+// returns the parts. Each part has the scale, scale, and the parts always sum
+// to total. For example, allocating 100.00 using the ratios 1, 1, and 1 at a
+// scale of 2 returns 33.34, 33.33, and 33.33.
+//
+// Allocate uses the largest remainder (Hamilton) method: each part is first
+// set to its exact share truncated to scale digits, then the remaining units
+// in the last place are given, one each, to the parts with the largest
+// truncated remainders. Ties are broken in favor of the part that appears
+// first in ratios, so Allocate is deterministic.
+//
+// If total has more than scale digits following the radix it is first rounded
+// to scale using its Context's RoundingMode; the parts then sum to the rounded
+// total. The parts have total's Context.
+//
+// Allocate will panic if total is not finite, if any ratio is negative or not
+// finite, or if the ratios sum to zero.
+func Allocate(total *Big, ratios []*Big, scale int32) []*Big {
+	if total.form&(nan|inf) != 0 {
+		panic("decimal.Allocate: total is not finite")
+	}
+	if len(ratios) == 0 {
+		return nil
+	}
+
+	// Put every ratio on the same scale so their unscaled values can be
+	// compared directly.
+	var rs int32
+	for _, r := range ratios {
+		if r.form&(nan|inf) != 0 || r.Sign() < 0 {
+			panic("decimal.Allocate: ratios must be finite and non-negative")
+		}
+		if r.scale > rs {
+			rs = r.scale
+		}
+	}
+	var (
+		weights = make([]*big.Int, len(ratios))
+		sum     = new(big.Int)
+		tmp     = new(Big)
+	)
+	for i, r := range ratios {
+		weights[i] = unscaledOf(new(big.Int), tmp.Copy(r).Quantize(rs))
+		sum.Add(sum, weights[i])
+	}
+	if sum.Sign() == 0 {
+		panic("decimal.Allocate: ratios sum to zero")
+	}
+
+	// Allocate the absolute value of total in units in the last place, then
+	// restore its sign.
+	units := unscaledOf(new(big.Int), tmp.Copy(total).Quantize(scale))
+	neg := units.Sign() < 0
+	units.Abs(units)
+
+	var (
+		parts  = make([]*big.Int, len(ratios))
+		shares = make([]share, len(ratios))
+		left   = new(big.Int).Set(units)
+	)
+	for i, w := range weights {
+		// part := ⌊units * w / sum⌋
+		parts[i], shares[i].rem = new(big.Int).QuoRem(
+			new(big.Int).Mul(units, w), sum, new(big.Int))
+		shares[i].i = i
+		left.Sub(left, parts[i])
+	}
+
+	// left < len(ratios) since each part was truncated by less than one unit.
+	sort.Stable(byRemainder(shares))
+	for n := left.Int64(); n > 0; n-- {
+		p := parts[shares[n-1].i]
+		p.Add(p, oneInt)
+	}
+
+	z := make([]*Big, len(parts))
+	for i, p := range parts {
+		if neg {
+			p.Neg(p)
+		}
+		z[i] = new(Big)
+		z[i].Context = total.Context
+		if p.IsInt64() {
+			z[i].SetMantScale(p.Int64(), scale)
+		} else {
+			z[i].SetBigMantScale(p, scale)
+		}
+		z[i].scale = scale
+	}
+	return z
+}
+
+// share is a part's index and the remainder of its truncated exact share.
+type share struct {
+	i   int
+	rem *big.Int
+}
+
+// byRemainder sorts shares by descending remainder.
+type byRemainder []share
+
+func (b byRemainder) Len() int           { return len(b) }
+func (b byRemainder) Less(i, j int) bool { return b[i].rem.Cmp(b[j].rem) > 0 }
+func (b byRemainder) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// unscaledOf sets z to x's unscaled value and returns z.
+func unscaledOf(z *big.Int, x *Big) *big.Int {
+	if x.form <= nzero {
+		return z.SetInt64(0)
+	}
+	if x.isCompact() {
+		return z.SetInt64(x.compact)
+	}
+	return z.Set(&x.unscaled)
+}
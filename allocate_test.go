@@ -0,0 +1,60 @@
+package decimal
+
+import "testing"
+
+func TestAllocate(t *testing.T) {
+	for i, test := range [...]struct {
+		total  string
+		ratios []string
+		scale  int32
+		parts  []string
+	}{
+		0: {"100.00", []string{"1", "1", "1"}, 2, []string{"33.34", "33.33", "33.33"}},
+		1: {"100", []string{"1", "1", "1"}, 2, []string{"33.34", "33.33", "33.33"}},
+		2: {"-100.00", []string{"1", "1", "1"}, 2, []string{"-33.34", "-33.33", "-33.33"}},
+		3: {"0.05", []string{"1", "1", "1", "1", "1", "1", "1", "1", "1", "1"}, 2,
+			[]string{"0.01", "0.01", "0.01", "0.01", "0.01", "0", "0", "0", "0", "0"}},
+		4: {"10", []string{"0.7", "0.2", "0.1"}, 2, []string{"7", "2", "1"}},
+		5: {"1", []string{"1", "2"}, 0, []string{"0", "1"}},
+		6: {"100.005", []string{"1", "1"}, 2, []string{"50", "50"}},
+		7: {"10", []string{"0", "1"}, 2, []string{"0", "10"}},
+		8: {"12345678901234567890.12", []string{"1", "1", "1"}, 2,
+			[]string{"4115226300411522630.04", "4115226300411522630.04", "4115226300411522630.04"}},
+		9: {"1000", []string{"33.3", "33.3", "33.4"}, -1, []string{"330", "330", "340"}},
+	} {
+		ratios := make([]*Big, len(test.ratios))
+		for j, r := range test.ratios {
+			ratios[j] = newbig(t, r)
+		}
+		total := newbig(t, test.total)
+		parts := Allocate(total, ratios, test.scale)
+		if len(parts) != len(test.parts) {
+			t.Fatalf("#%d: wanted %d parts, got %d", i, len(test.parts), len(parts))
+		}
+
+		sum := new(Big)
+		sum.Context.SetPrecision(50)
+		for j, p := range parts {
+			testFormZero(t, p, "Allocate")
+			if want := newbig(t, test.parts[j]); p.Cmp(want) != 0 {
+				t.Fatalf("#%d.%d: wanted %s, got %s", i, j, want, p)
+			}
+			if p.Scale() != test.scale {
+				t.Fatalf("#%d.%d: wanted scale %d, got %d", i, j, test.scale, p.Scale())
+			}
+			sum.Add(sum, p)
+		}
+		if sum.Cmp(total.Quantize(test.scale)) != 0 {
+			t.Fatalf("#%d: wanted sum %s, got %s", i, total, sum)
+		}
+	}
+
+	for i, ratios := range [...][]*Big{
+		{New(1, 0), New(-1, 0)},
+		{New(0, 0), New(0, 0)},
+	} {
+		if !didPanic(func() { Allocate(New(100, 0), ratios, 2) }) {
+			t.Fatalf("#%d: wanted panic", i)
+		}
+	}
+}
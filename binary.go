@@ -0,0 +1,214 @@
+package decimal
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ericlagergren/decimal/internal/c"
+)
+
+// The binary encoding of a Big is
+//
+//     version   byte
+//     form      byte
+//     mode      byte    // Context.OperatingMode
+//     rounding  byte    // Context.RoundingMode
+//     traps     uvarint // Context.Traps
+//     precision varint  // Context's precision
+//
+// followed by, if the Big is zero or finite,
+//
+//     scale     varint
+//     coeff     uvarint
+//
+// If the form byte has bigCoeff set, the coefficient does not fit into a
+// uvarint and coeff is instead
+//
+//     length    uvarint
+//     coeff     [length]byte // big-endian
+//
+// The form byte is the Big's form. The sign of a finite number's coefficient
+// is stored in the form's sign bit, so coeff is always the magnitude.
+// Context.Err, Context.Conditions, and Context.JSONFormat are not encoded.
+
+// binaryVersion is the current version of the binary encoding.
+const binaryVersion = 1
+
+// bigCoeff is set in the encoded form byte if the coefficient is stored as a
+// length-prefixed big-endian integer instead of a uvarint.
+const bigCoeff = 0x80
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (x *Big) MarshalBinary() ([]byte, error) {
+	f := byte(x.form)
+	if x.form == finite {
+		if x.Signbit() {
+			f |= byte(sign)
+		}
+		if x.isInflated() {
+			f |= bigCoeff
+		}
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	b := make([]byte, 4, 16)
+	b[0] = binaryVersion
+	b[1] = f
+	b[2] = byte(x.Context.OperatingMode)
+	b[3] = byte(x.Context.RoundingMode)
+	b = append(b, buf[:binary.PutUvarint(buf[:], uint64(x.Context.Traps))]...)
+	b = append(b, buf[:binary.PutVarint(buf[:], int64(x.Context.precision))]...)
+	if x.form&(nan|inf) != 0 {
+		return b, nil
+	}
+
+	b = append(b, buf[:binary.PutVarint(buf[:], int64(x.scale))]...)
+	switch {
+	case x.form != finite:
+		b = append(b, 0)
+	case x.isCompact():
+		b = append(b, buf[:binary.PutUvarint(buf[:], absUint64(x.compact))]...)
+	default:
+		m := x.unscaled.Bytes()
+		b = append(b, buf[:binary.PutUvarint(buf[:], uint64(len(m)))]...)
+		b = append(b, m...)
+	}
+	return b, nil
+}
+
+var _ encoding.BinaryMarshaler = (*Big)(nil)
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It also sets z's
+// Context, except for Context.Err and Context.Conditions, to the encoded
+// Context. z's Context.JSONFormat is left unchanged.
+func (z *Big) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errors.New("Big.UnmarshalBinary: buffer too small")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("Big.UnmarshalBinary: unsupported version: %d", data[0])
+	}
+
+	var (
+		f     = form(data[1] &^ bigCoeff)
+		isBig = data[1]&bigCoeff != 0
+		neg   = false
+	)
+	if f == finite|sign {
+		f, neg = finite, true
+	}
+	switch f {
+	case zero, nzero, snan, qnan, pinf, ninf:
+		if isBig {
+			return errors.New("Big.UnmarshalBinary: invalid form")
+		}
+	case finite:
+	default:
+		return errors.New("Big.UnmarshalBinary: invalid form")
+	}
+
+	ctx := Context{
+		OperatingMode: OperatingMode(data[2]),
+		RoundingMode:  RoundingMode(data[3]),
+	}
+	if ctx.OperatingMode > GDA || ctx.RoundingMode > ToPositiveInf {
+		return errors.New("Big.UnmarshalBinary: invalid Context")
+	}
+	data = data[4:]
+	traps, n := binary.Uvarint(data)
+	if n <= 0 || traps > math.MaxUint32 {
+		return errors.New("Big.UnmarshalBinary: invalid Context")
+	}
+	ctx.Traps = Condition(traps)
+	data = data[n:]
+	prec, n := binary.Varint(data)
+	if n <= 0 || prec < noPrecision || prec > MaxPrecision {
+		return errors.New("Big.UnmarshalBinary: invalid Context")
+	}
+	ctx.precision = int32(prec)
+	data = data[n:]
+
+	var (
+		scale   int64
+		compact int64
+		coeff   big.Int
+	)
+	if f&(nan|inf) == 0 {
+		if scale, n = binary.Varint(data); n <= 0 || scale < MinScale || scale > MaxScale {
+			return errors.New("Big.UnmarshalBinary: invalid scale")
+		}
+		data = data[n:]
+
+		u, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("Big.UnmarshalBinary: invalid coefficient")
+		}
+		data = data[n:]
+		switch {
+		case f != finite:
+			if u != 0 {
+				return errors.New("Big.UnmarshalBinary: invalid coefficient")
+			}
+		case isBig:
+			if u == 0 || u > uint64(len(data)) {
+				return errors.New("Big.UnmarshalBinary: invalid coefficient")
+			}
+			if coeff.SetBytes(data[:u]).Sign() == 0 {
+				return errors.New("Big.UnmarshalBinary: invalid coefficient")
+			}
+			if neg {
+				coeff.Neg(&coeff)
+			}
+			compact = c.Inflated
+			if coeff.IsInt64() && coeff.Int64() != c.Inflated {
+				compact = coeff.Int64()
+			}
+			data = data[u:]
+		default:
+			if u == 0 || u > 1<<63 || u == 1<<63 && !neg {
+				return errors.New("Big.UnmarshalBinary: invalid coefficient")
+			}
+			compact = int64(u)
+			if neg {
+				compact = -compact
+			}
+			if compact == c.Inflated {
+				coeff.SetInt64(compact)
+			}
+		}
+	}
+	if len(data) != 0 {
+		return errors.New("Big.UnmarshalBinary: trailing data")
+	}
+
+	ctx.JSONFormat = z.Context.JSONFormat
+	z.Context = ctx
+	z.form = f
+	z.scale = int32(scale)
+	z.compact = compact
+	if compact == c.Inflated {
+		z.unscaled.Set(&coeff)
+	}
+	return nil
+}
+
+var _ encoding.BinaryUnmarshaler = (*Big)(nil)
+
+// GobEncode implements gob.GobEncoder.
+func (x *Big) GobEncode() ([]byte, error) {
+	return x.MarshalBinary()
+}
+
+var _ gob.GobEncoder = (*Big)(nil)
+
+// GobDecode implements gob.GobDecoder.
+func (z *Big) GobDecode(data []byte) error {
+	return z.UnmarshalBinary(data)
+}
+
+var _ gob.GobDecoder = (*Big)(nil)
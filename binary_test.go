@@ -0,0 +1,142 @@
+package decimal
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestBig_MarshalBinary(t *testing.T) {
+	gda := Context128
+	gda.RoundingMode = ToNegativeInf
+
+	for i, test := range [...]struct {
+		s   string
+		ctx Context
+	}{
+		0:  {"0", Context{}},
+		1:  {"-0", gda},
+		2:  {"0.000", gda},
+		3:  {"1", Context{}},
+		4:  {"-12.345", gda},
+		5:  {"1E+100", Context{}},
+		6:  {"9223372036854775807", Context{}},
+		7:  {"-9223372036854775808", Context{}},
+		8:  {"123456789012345678901234567890.123", gda},
+		9:  {"-123456789012345678901234567890.123", gda},
+		10: {"Infinity", gda},
+		11: {"-Infinity", gda},
+		12: {"NaN", gda},
+		13: {"sNaN", gda},
+	} {
+		x := new(Big)
+		x.Context = test.ctx
+		if _, ok := x.SetString(test.s); !ok {
+			t.Fatalf("#%d: invalid number: %q", i, test.s)
+		}
+		b, err := x.MarshalBinary()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		y := new(Big)
+		if err := y.UnmarshalBinary(b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if x.form != y.form || x.Signbit() != y.Signbit() {
+			t.Fatalf("#%d: wanted form %v, got %v", i, x.form, y.form)
+		}
+		if x.form == finite && (x.Cmp(y) != 0 || x.Scale() != y.Scale()) {
+			t.Fatalf(`#%d:
+wanted: %q (scale %d)
+got   : %q (scale %d)
+`, i, x, x.Scale(), y, y.Scale())
+		}
+		x.Context.Conditions, x.Context.Err = 0, nil
+		if x.Context != y.Context {
+			t.Fatalf("#%d: wanted %+v, got %+v", i, x.Context, y.Context)
+		}
+	}
+}
+
+func TestBig_UnmarshalBinary(t *testing.T) {
+	valid, _ := New(-12345, 2).MarshalBinary()
+	for i, b := range [...][]byte{
+		0:  nil,
+		1:  {1, 2, 0},
+		2:  {2, 2, 0, 0, 0, 0, 0, 1},               // bad version
+		3:  {1, 0x40, 0, 0, 0, 0, 0, 0},            // bad form
+		4:  {1, 0x10 | bigCoeff, 0, 0, 0, 0},       // Inf with big coefficient
+		5:  {1, 2, 2, 0, 0, 0, 0, 1},               // bad OperatingMode
+		6:  {1, 2, 0, 9, 0, 0, 0, 1},               // bad RoundingMode
+		7:  {1, 2, 0, 0, 0, 0, 0, 0},               // finite with zero coefficient
+		8:  {1, 2 | bigCoeff, 0, 0, 0, 0, 0, 3, 1}, // truncated coefficient
+		9:  append(valid, 0),                       // trailing data
+		10: valid[:len(valid)-1],
+		11: {1, 2 | bigCoeff, 0, 0, 0, 0, 0, 1, 0},    // zero big coefficient
+		12: {1, 2 | bigCoeff, 0, 0, 0, 0, 0, 2, 0, 0}, // zero big coefficient
+	} {
+		if err := new(Big).UnmarshalBinary(b); err == nil {
+			t.Fatalf("#%d: %v: wanted error, got nil", i, b)
+		}
+	}
+
+	// JSONFormat isn't encoded, so it's left alone.
+	var z Big
+	z.Context.JSONFormat = JSONNumber
+	if err := z.UnmarshalBinary(valid); err != nil {
+		t.Fatal(err)
+	}
+	if z.Context.JSONFormat != JSONNumber {
+		t.Fatalf("wanted JSONNumber, got %d", z.Context.JSONFormat)
+	}
+}
+
+func TestBig_UnmarshalBinaryBigCoeff(t *testing.T) {
+	for i, test := range [...]struct {
+		b       []byte
+		want    string
+		compact bool
+	}{
+		// Big coefficients that fit in an int64 are made compact.
+		0: {[]byte{1, 2 | bigCoeff, 0, 0, 0, 0, 4, 2, 0x30, 0x39}, "123.45", true},
+		1: {[]byte{1, 3 | bigCoeff, 0, 0, 0, 0, 0, 1, 7}, "-7", true},
+		2: {[]byte{1, 3 | bigCoeff, 0, 0, 0, 0, 0, 8, 0x80, 0, 0, 0, 0, 0, 0, 0}, "-9223372036854775808", true},
+		// 2^63-1 is c.Inflated, so it can't be compact.
+		3: {[]byte{1, 2 | bigCoeff, 0, 0, 0, 0, 0, 8, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "9223372036854775807", false},
+		4: {[]byte{1, 2 | bigCoeff, 0, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0}, "18446744073709551616", false},
+	} {
+		var z Big
+		if err := z.UnmarshalBinary(test.b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z.String() != test.want {
+			t.Fatalf("#%d: wanted %s, got %s", i, test.want, &z)
+		}
+		if z.isCompact() != test.compact {
+			t.Fatalf("#%d: wanted compact == %t, got %t", i, test.compact, z.isCompact())
+		}
+	}
+}
+
+func TestBig_Gob(t *testing.T) {
+	type entry struct {
+		Key   string
+		Value *Big
+	}
+	x := entry{Key: "pi", Value: Context64.New(31415926535, 10)}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(x); err != nil {
+		t.Fatal(err)
+	}
+	var y entry
+	if err := gob.NewDecoder(&buf).Decode(&y); err != nil {
+		t.Fatal(err)
+	}
+	if y.Key != x.Key || y.Value.Cmp(x.Value) != 0 {
+		t.Fatalf("wanted %v, got %v", x, y)
+	}
+	if y.Value.Context.OperatingMode != GDA || y.Value.Context.Precision() != 16 {
+		t.Fatalf("wanted %+v, got %+v", x.Value.Context, y.Value.Context)
+	}
+}
@@ -45,6 +45,10 @@ type Context struct {
 	// (digits following the radix) should be rounded. This can occur during
 	// "lossy" operations like division.
 	RoundingMode RoundingMode
+
+	// JSONFormat determines how MarshalJSON encodes the decimal. The default
+	// is JSONString.
+	JSONFormat JSONFormat
 }
 
 // New is shorthand to create a Big from a Context.
@@ -77,12 +81,10 @@ func (c *Context) SetPrecision(prec int32) {
 	}
 }
 
-// The following are called ContextXX instead of DecimalXX
-// to reserve the DecimalXX namespace for future decimal types.
-
 // The following Contexts are based on IEEE 754R. Each Context's RoundingMode is
 // ToNearestEven, OperatingMode is GDA, and traps are set to every exception
-// other than Inexact, Rounded, and Subnormal.
+// other than Inexact, Rounded, and Subnormal. Decimal32, Decimal64, and
+// Decimal128 are the formats' encodings.
 var (
 	// Context32 is the IEEE 754R Decimal32 format.
 	Context32 = Context{
@@ -30,7 +30,6 @@
 package decimal
 
 import (
-	"bytes"
 	"encoding"
 	"errors"
 	"fmt"
@@ -40,7 +39,6 @@ import (
 	"regexp"
 	"runtime"
 	"strconv"
-	"strings"
 
 	"github.com/ericlagergren/decimal/internal/arith"
 	"github.com/ericlagergren/decimal/internal/arith/checked"
@@ -202,10 +200,17 @@ func (x *Big) isInflated() bool { return !x.isCompact() }
 // Abs sets z to the absolute value of x and returns z.
 func (z *Big) Abs(x *Big) *Big {
 	if x.form == finite {
-		if x.isCompact() {
-			z.compact = arith.Abs(x.compact)
-		} else {
+		switch {
+		case x.isInflated():
 			z.unscaled.Abs(&x.unscaled)
+			z.compact = c.Inflated
+		case x.compact == math.MinInt64:
+			// |x.compact| overflows.
+			z.unscaled.SetInt64(x.compact)
+			z.unscaled.Abs(&z.unscaled)
+			z.compact = c.Inflated
+		default:
+			z.compact = arith.Abs(x.compact)
 		}
 		z.scale = x.scale
 		z.form = finite
@@ -352,6 +357,20 @@ func (z *Big) addBig(x, y *Big) *Big {
 	return z
 }
 
+// Append appends to buf the string form of x, as generated by x.Text, and
+// returns the extended buffer. Unless x's coefficient is too large to fit in
+// an int64, Append does not allocate if buf has enough capacity.
+func (x *Big) Append(buf []byte, fmt byte, prec int) []byte {
+	f := formatter{buf: buf, prec: prec, width: noWidth}
+	if prec < 0 {
+		f.prec = noPrec
+	}
+	if !f.verb(x, fmt, false) {
+		return append(buf, '%', fmt)
+	}
+	return f.buf
+}
+
 // BitLen returns the absolute value of x in bits. The result is undefined if
 // x is an infinity or a NaN value.
 func (x *Big) BitLen() int {
@@ -599,6 +618,12 @@ func (x *Big) Float(z *big.Float) *big.Float {
 // 	%v: same as %s
 // 	%e: -d.dddd±edd
 // 	%E: -d.dddd±Edd
+// 	%#e: -ddd.dddd±edd, like %e but the exponent is a multiple of three
+// 	%#E: -ddd.dddd±Edd, like %E but the exponent is a multiple of three
+//
+// Like %e, %#e always uses scientific notation, so 1200000 is formatted as
+// "1.2e+6" and 12000000 as "12e+6". See EngString for the GDA spec's
+// to-engineering-string, which only uses an exponent when one is needed.
 // 	%f: -dddd.dd
 // 	%g: same as %f
 //
@@ -608,8 +633,8 @@ func (x *Big) Float(z *big.Float) *big.Float {
 // is the number of significant digits.
 //
 // Format honors all flags (such as '+' and ' ') in the same manner as the fmt
-// package, except for '#'. Unless used in conjunction with %v, %q, or %p, the
-// '#' flag will be ignored; decimals have no defined hexadeximal or octal
+// package, except for '#'. Unless used in conjunction with %e, %E, %v, %q, or
+// %p, the '#' flag will be ignored; decimals have no defined hexadeximal or octal
 // representation.
 //
 // %+v, %#v, %T, %#p, and %p all honor the formats specified in the fmt
@@ -633,26 +658,13 @@ func (x *Big) Format(s fmt.State, c rune) {
 		f      = formatter{prec: prec, width: width}
 	)
 
-	// If we need to left pad then we need to first write our string into an
-	// empty buffer.
-	if lpZero {
-		f.w = new(bytes.Buffer)
-	} else {
-		f.w = stateWrapper{s}
-	}
-
 	if plus {
 		f.sign = '+'
 	} else if space {
 		f.sign = ' '
 	}
 
-	// noE is a placeholder for formats that do not use scientific notation
-	// and don't require 'e' or 'E'
-	const noE = 0
 	switch c {
-	case 's', 'd':
-		f.format(x, normal, 'e')
 	case 'q':
 		// The fmt package's docs specify that the '+' flag
 		// "guarantee[s] ASCII-only output for %q (%+q)"
@@ -667,26 +679,6 @@ func (x *Big) Format(s fmt.State, c rune) {
 		f.WriteByte(quote)
 		f.format(x, normal, 'e')
 		f.WriteByte(quote)
-	case 'e', 'E':
-		f.format(x, sci, byte(c))
-	case 'f':
-		if f.prec == noPrec {
-			f.prec = 0
-		}
-		// %f's precision means "number of digits after the radix"
-		if x.scale > 0 {
-			if trail := x.Precision() - int(x.scale); trail < f.prec {
-				f.prec += int(x.scale)
-			} else {
-				f.prec = int(x.scale) + trail
-			}
-		} else {
-			f.prec += x.Precision()
-		}
-		f.format(x, plain, noE)
-	case 'g':
-		// %g's precision means "number of significant digits"
-		f.format(x, plain, noE)
 
 	// Make sure we return from the following two cases.
 	case 'v':
@@ -723,25 +715,37 @@ func (x *Big) Format(s fmt.State, c rune) {
 		fmt.Fprintf(s, "%"+specs+"v", (*Big)(x))
 		return
 	default:
-		fmt.Fprintf(s, "%%!%c(*decimal.Big=%s)", c, x.String())
-		return
-	}
-
-	needPad := f.n < int64(width)
-	if needPad && lpZero {
-		io.CopyN(s, zeroReader{}, int64(width)-f.n)
-		needPad = false
+		if c > 0xff || !f.verb(x, byte(c), hash) {
+			fmt.Fprintf(s, "%%!%c(*decimal.Big=%s)", c, x.String())
+			return
+		}
 	}
 
-	// TODO(eric): find a better way of doing this.
-	// If we had to write into a temp buffer, copy it over to the State.
-	if r, ok := f.w.(*bytes.Buffer); ok {
-		io.Copy(s, r)
+	n := int64(len(f.buf))
+	if n >= int64(width) {
+		s.Write(f.buf)
+		return
 	}
+	pad := int64(width) - n
 
-	// Right pad if need be.
-	if needPad && dash {
-		io.CopyN(s, spaceReader{}, int64(width)-f.n)
+	switch {
+	case dash:
+		// Right pad.
+		s.Write(f.buf)
+		io.CopyN(s, spaceReader{}, pad)
+	case lpZero && x != nil && x.form <= finite:
+		// Like the fmt package, put the zeros after the sign and don't zero
+		// pad infinities or NaN values.
+		b := f.buf
+		if c != 'q' && (b[0] == '-' || b[0] == '+' || b[0] == ' ') {
+			s.Write(b[:1])
+			b = b[1:]
+		}
+		io.CopyN(s, zeroReader{}, pad)
+		s.Write(b)
+	default:
+		io.CopyN(s, spaceReader{}, pad)
+		s.Write(f.buf)
 	}
 }
 
@@ -891,12 +895,9 @@ func (x *Big) IsInt() bool {
 
 // MarshalText implements encoding.TextMarshaler.
 func (x *Big) MarshalText() ([]byte, error) {
-	var (
-		b bytes.Buffer
-		f = formatter{w: &b, prec: noPrec, width: noWidth}
-	)
+	f := formatter{prec: noPrec, width: noWidth}
 	f.format(x, normal, 'e')
-	return b.Bytes(), nil
+	return f.buf, nil
 }
 
 // Mul sets z to x * y and returns z.
@@ -941,6 +942,21 @@ func (z *Big) Mul(x, y *Big) *Big {
 	// 0 * y
 	// x * 0
 	z.form = zero
+	if x.Signbit() != y.Signbit() {
+		z.form = nzero
+	}
+	scale, ok := checked.Add32(x.scale, y.scale)
+	if !ok {
+		// The product is still zero, but its exponent is out of range, so
+		// clamp it.
+		if x.scale > 0 {
+			z.scale = MaxScale
+			return z.signal(Underflow|Clamped, errUnderflow)
+		}
+		z.scale = MinScale
+		return z.signal(Overflow|Clamped, errOverflow)
+	}
+	z.scale = scale
 	return z
 }
 
@@ -991,11 +1007,17 @@ func (z *Big) mulBig(x, y *Big) *Big {
 // NaN has no negative representation, and will result in an error.
 func (z *Big) Neg(x *Big) *Big {
 	if x.form == finite {
-		if x.isCompact() {
-			z.compact = -x.compact
-		} else {
+		switch {
+		case x.isInflated():
 			z.unscaled.Neg(&x.unscaled)
 			z.compact = c.Inflated
+		case x.compact == math.MinInt64:
+			// -x.compact overflows.
+			z.unscaled.SetInt64(x.compact)
+			z.unscaled.Neg(&z.unscaled)
+			z.compact = c.Inflated
+		default:
+			z.compact = -x.compact
 		}
 		z.scale = x.scale
 		z.form = x.form
@@ -1047,16 +1069,123 @@ func (x *Big) Precision() int {
 	return arith.BigLength(&x.unscaled)
 }
 
+// Quantize sets z to the number equal in value and sign to z with the scale, n,
+// rounding z using its Context's RoundingMode if necessary, and returns z.
+// Unlike Round, Quantize does not modify z's Context. No rounding will occur if
+// n >= z's scale. The result is undefined if z is not finite.
+//
+// For example, if the RoundingMode is ToNearestEven, 1.235 quantized to a scale
+// of 2 is 1.24 and 1.2 quantized to a scale of 3 is 1.200.
+func (z *Big) Quantize(n int32) *Big {
+	if z.form != finite {
+		if z.form <= nzero {
+			z.scale = n
+		}
+		return z
+	}
+	if z.scale == n {
+		return z
+	}
+
+	shift := int64(z.scale) - int64(n)
+	z.scale = n
+
+	// Inflate z.
+	if shift < 0 {
+		if z.isCompact() {
+			if v, ok := checked.MulPow10(z.compact, int32(-shift)); ok {
+				z.compact = v
+				return z
+			}
+			z.unscaled.SetInt64(z.compact)
+			z.compact = c.Inflated
+		}
+		z.unscaled.Mul(&z.unscaled, pow.BigTen(-shift))
+		return z
+	}
+
+	z.Context.Conditions |= Rounded
+	if z.isCompact() {
+		if d, ok := pow.Ten64(shift); ok {
+			q, r := z.compact/d, z.compact%d
+			if r != 0 {
+				z.Context.Conditions |= Inexact
+				if z.needsInc(d, r, z.compact > 0, q&1 != 0) {
+					if z.compact < 0 {
+						q--
+					} else {
+						q++
+					}
+				}
+			}
+			if q == 0 {
+				z.form = zero
+				if z.compact < 0 {
+					z.form = nzero
+				}
+			}
+			z.compact = q
+			return z
+		}
+		z.unscaled.SetInt64(z.compact)
+	}
+
+	d := pow.BigTen(shift)
+	r := new(big.Int)
+	pos := z.unscaled.Sign() > 0
+	x := new(big.Int).Set(&z.unscaled)
+	q, r := z.unscaled.QuoRem(x, d, r)
+	if r.Sign() != 0 {
+		z.Context.Conditions |= Inexact
+		if z.needsIncBig(d, r, pos, q.Bit(0) != 0) {
+			if pos {
+				q.Add(q, oneInt)
+			} else {
+				q.Sub(q, oneInt)
+			}
+		}
+	}
+	if q.IsInt64() {
+		if z.compact = q.Int64(); z.compact == 0 {
+			z.form = zero
+			if !pos {
+				z.form = nzero
+			}
+		}
+	} else {
+		z.compact = c.Inflated
+	}
+	return z
+}
+
 // Quo sets z to x / y and returns z.
 func (z *Big) Quo(x, y *Big) *Big {
 	// TODO(eric): rewrite Quo since it's... slow.
+	// The ideal exponent of the quotient is x's exponent less y's.
+	ideal := int64(x.scale) - int64(y.scale)
+
 	if x.form == finite && y.form == finite {
+		inexact := z.Context.Conditions & Inexact
+		z.Context.Conditions &^= Inexact
+
 		// set z.form == finite inside the quo* methods.
 		// x / y (common case)
 		if x.isCompact() && y.isCompact() {
-			return z.quoCompact(x, y)
+			z.quoCompact(x, y)
+		} else {
+			z.quoBig(x, y)
+		}
+
+		// "...if the result is exact, the coefficient is reduced until the
+		// exponent is the ideal exponent or the coefficient has no trailing
+		// zeros."
+		//
+		// - http://speleotrove.com/decimal/daops.html#refdivide
+		if z.Context.OperatingMode == GDA && z.Context.Conditions&Inexact == 0 {
+			z.reduce(ideal)
 		}
-		return z.quoBig(x, y)
+		z.Context.Conditions |= inexact
+		return z
 	}
 
 	// NaN / NaN
@@ -1081,6 +1210,12 @@ func (z *Big) Quo(x, y *Big) *Big {
 		// 0 / y
 		// x / ±Inf
 		z.form = zero
+		if x.Signbit() != y.Signbit() {
+			z.form = nzero
+		}
+		if y.form&inf == 0 && ideal >= MinScale && ideal <= MaxScale {
+			z.scale = int32(ideal)
+		}
 		return z
 	}
 
@@ -1116,7 +1251,7 @@ func (z *Big) quoCompactCore(x int64, xs, xp int32, y int64, ys, yp int32) *Big
 		return z.xflow(ys > 0, true)
 	}
 
-	// Multiply y by 10 if x' > y'
+	// Multiply y by 10 if x' >= y'
 	if cmpNorm(x, xp, y, yp) {
 		yp--
 	}
@@ -1171,29 +1306,64 @@ func (z *Big) quoCompactCore(x int64, xs, xp int32, y int64, ys, yp int32) *Big
 func (z *Big) quoAndRound(x, y int64) *Big {
 	z.form = finite
 
-	// Quotient
+	// Quotient and remainder
 	z.compact = x / y
+	r := x % y
+	if r != 0 {
+		z.Context.Conditions |= Inexact | Rounded
+	}
 
 	// ToZero means we can ignore remainder.
 	if z.Context.RoundingMode == ToZero {
 		return z
 	}
 
-	// Remainder
-	r := x % y
 	if r == 0 {
 		return z.simplify()
 	}
-	if z.needsInc(y, r, sign > 0, z.compact&1 != 0) {
-		if (x < 0) == (y < 0) {
+	pos := (x < 0) == (y < 0)
+	if z.needsInc(y, r, pos, z.compact&1 != 0) {
+		if pos {
 			z.compact++
 		} else {
 			z.compact--
 		}
+		// Rounding 99...9 up carries into a new digit.
+		if p := z.Context.Precision(); p > 0 && arith.Length(z.compact) > int(p) {
+			z.compact /= 10
+			z.scale--
+		}
 	}
 	return z
 }
 
+// reduce removes trailing zeros from z's coefficient while z's scale is
+// greater than scale.
+func (z *Big) reduce(scale int64) {
+	if z.form != finite {
+		return
+	}
+	if z.isCompact() {
+		for int64(z.scale) > scale && z.compact%10 == 0 {
+			z.compact /= 10
+			z.scale--
+		}
+		return
+	}
+	var q, r big.Int
+	for int64(z.scale) > scale {
+		q.QuoRem(&z.unscaled, c.TenInt, &r)
+		if r.Sign() != 0 {
+			break
+		}
+		z.unscaled.Set(&q)
+		z.scale--
+	}
+	if z.unscaled.IsInt64() {
+		z.compact = z.unscaled.Int64()
+	}
+}
+
 func (z *Big) simplify() *Big {
 	if z.scale == z.Context.Precision() {
 		return z
@@ -1226,13 +1396,20 @@ func (z *Big) quoBigCore(
 	xb *big.Int, xc int64, xs, xp int32,
 	yb *big.Int, yc int64, ys, yp int32,
 ) *Big {
+	if xc != c.Inflated {
+		xb = big.NewInt(xc)
+	}
+	if yc != c.Inflated {
+		yb = big.NewInt(yc)
+	}
+
 	sdiff, ok := checked.Sub32(xs, ys)
 	if !ok {
 		// -x - y ∈ [-1<<31, 1<<31-1]
 		return z.xflow(ys > 0, true)
 	}
 
-	// Multiply y by 10 if x' > y'
+	// Multiply y by 10 if x' >= y'
 	if cmpNormBig(xb, xp, yb, yp) {
 		yp--
 	}
@@ -1253,9 +1430,6 @@ func (z *Big) quoBigCore(
 
 	// Inflate x.
 	if shift > 0 {
-		if yc != c.Inflated {
-			yb = big.NewInt(yc)
-		}
 		if xc == c.Inflated {
 			xb = checked.MulBigPow10(new(big.Int).Set(xb), shift)
 		} else {
@@ -1279,9 +1453,6 @@ func (z *Big) quoBigCore(
 		// -x - y ∈ [-1<<31, ..., 1<<31-1]
 		return z.xflow(yp > 0, true)
 	}
-	if xc != c.Inflated {
-		xb = big.NewInt(xc)
-	}
 	// Inflate y.
 	if yc == c.Inflated {
 		yb = checked.MulBigPow10(new(big.Int).Set(yb), shift)
@@ -1301,6 +1472,9 @@ func (z *Big) quoBigAndRound(x, y *big.Int) *Big {
 
 	r := new(big.Int)
 	q, r := z.unscaled.QuoRem(x, y, r)
+	if r.Sign() != 0 {
+		z.Context.Conditions |= Inexact | Rounded
+	}
 
 	if z.Context.RoundingMode == ToZero && z.scale == z.Context.Precision() {
 		return z
@@ -1311,12 +1485,18 @@ func (z *Big) quoBigAndRound(x, y *big.Int) *Big {
 	}
 	tmp := new(big.Int).And(q, oneInt)
 	odd := tmp.Sign() != 0
-	if z.needsIncBig(y, r, sign > 0, odd) {
-		if (x.Sign() < 0) == (y.Sign() < 0) {
+	pos := (x.Sign() < 0) == (y.Sign() < 0)
+	if z.needsIncBig(y, r, pos, odd) {
+		if pos {
 			z.unscaled.Add(&z.unscaled, tmp.SetInt64(+1))
 		} else {
 			z.unscaled.Add(&z.unscaled, tmp.SetInt64(-1))
 		}
+		// Rounding 99...9 up carries into a new digit.
+		if p := z.Context.Precision(); p > 0 && arith.BigLength(&z.unscaled) > int(p) {
+			z.unscaled.Quo(&z.unscaled, c.TenInt)
+			z.scale--
+		}
 	}
 	return z
 }
@@ -1434,17 +1614,13 @@ func (z *Big) Round(n int32) *Big {
 // Scale returns x's scale.
 func (x *Big) Scale() int32 { return x.scale }
 
-// Scan implements fmt.Scanner.
+// Scan implements fmt.Scanner. It skips leading white space and then reads a
+// number in the format described in Decoder.Decode, leaving any following
+// input unread.
 func (z *Big) Scan(state fmt.ScanState, verb rune) error {
-	toks, err := state.Token(true, func(c rune) bool {
-		// Small enough range that we balance speed with accuracy.
-		return c >= '+' && c <= 'e'
-	})
-	if err != nil {
-		return err
-	}
-	z.SetString(string(toks))
-	return nil
+	state.SkipSpace()
+	s := scanner{r: runeByteScanner{state}}
+	return s.scan(z)
 }
 
 var _ fmt.Scanner = (*Big)(nil)
@@ -1655,8 +1831,6 @@ var Regexp = regexp.MustCompile(`(?i)(((\+|-)?(\d+\.\d*|\.?\d+)([eE][+-]?\d+)?)|
 // example, ``NaN123''. These digits are otherwise ignored but are included for
 // robustness.
 func (z *Big) SetString(s string) (*Big, bool) {
-	// TODO(eric): write a scan(io.Reader) method.
-
 	if s == "" {
 		return z.signal(ConversionSyntax, errors.New(`SetString("")`)), false
 	}
@@ -1678,99 +1852,71 @@ func (z *Big) SetString(s string) (*Big, bool) {
 	// We deviate a little by being a tad bit more forgiving. For instance,
 	// we allow case-insensitive nan and infinity values.
 
-	switch parse.ParseSpecial(s) {
+	num := parse.ParseNumber(s, &z.unscaled)
+	if num.Form == parse.Invalid {
+		z.form = qnan
+		return z.signal(
+			ConversionSyntax,
+			errors.New("SetString: invalid syntax"),
+		), false
+	}
+	return z, z.setNumber(num) == nil
+}
+
+// setNumber sets z to num, whose coefficient ParseNumber stored in z.unscaled
+// if it didn't fit into a uint64. num must not be Invalid. If num's exponent is
+// out of range, z is set to ±Inf or ±0 and setNumber returns errOverflow or
+// errUnderflow.
+func (z *Big) setNumber(num parse.Number) error {
+	switch num.Form {
 	case parse.QNaN:
 		z.form = qnan
-		return z, true
+		return nil
 	case parse.SNaN:
 		z.form = snan
-		return z, true
+		return nil
 	case parse.PInf:
 		z.form = pinf
-		return z, true
+		return nil
 	case parse.NInf:
 		z.form = ninf
-		return z, true
+		return nil
 	}
 
-	var scale int32
-
-	// Check for a scientific string.
-	if i := strings.LastIndexAny(s, "Ee"); i > 0 {
-		eint, err := strconv.ParseInt(s[i+1:], 10, 32)
-		if err != nil {
-			if err.(*strconv.NumError).Err == strconv.ErrSyntax {
-				z.form = qnan
-				return z.signal(ConversionSyntax, err), false
-			}
-			// strconv.ErrRange.
-			return z.xflow(eint < 0, s[0] == '-'), false
-		}
-		s = s[:i]
-		scale = -int32(eint)
-	}
-
-	switch strings.Count(s, ".") {
-	case 0:
-		// OK
-	case 1:
-		i := strings.IndexByte(s, '.')
-		s = s[:i] + s[i+1:]
-		sc, ok := checked.Add32(scale, int32(len(s)-i))
-		if !ok {
-			// It's impossible for the scale to underflow here since the rhs will
-			// always be [0, len(s)]
-			return z.xflow(true, s[0] == '-'), false
-		}
-		scale = sc
-	default:
-		return z.signal(
-			ConversionSyntax,
-			errors.New("SetString: too many '.' in input"),
-		), false
+	if num.Scale < MinScale || num.Scale > MaxScale {
+		if z.xflow(num.Scale < 0, num.Neg); num.Scale < 0 {
+			return errOverflow
+		}
+		return errUnderflow
 	}
+	z.scale = int32(num.Scale)
 
-	var err error
-	z.form = finite
-	// Numbers == 19 can be out of range, but try the edge case anyway.
-	if len(s) <= 19 {
-		if z.compact, err = strconv.ParseInt(s, 10, 64); err != nil {
-			nerr, ok := err.(*strconv.NumError)
-			if !ok || nerr.Err == strconv.ErrSyntax {
-				z.form = qnan
-				return z.signal(ConversionSyntax, err), false
-			}
-			err = nerr.Err
-		} else if z.compact == 0 {
-			if s[0] == '-' {
-				z.form = nzero
-			} else {
-				z.form = zero
-			}
-		}
-		if z.compact == c.Inflated {
-			z.unscaled.SetInt64(z.compact)
+	if num.Inflated {
+		z.compact = c.Inflated
+		if num.Neg {
+			z.unscaled.Neg(&z.unscaled)
 		}
-	}
-	if (err == strconv.ErrRange && len(s) == 19) || len(s) > 19 {
-		if _, ok := z.unscaled.SetString(s, 10); !ok {
-			return z.signal(
-				ConversionSyntax,
-				// TODO(eric): a better error message?
-				errors.New("SetString: invalid syntax"),
-			), false
+		if z.unscaled.IsInt64() && z.unscaled.Int64() != c.Inflated {
+			z.compact = z.unscaled.Int64()
 		}
-		z.compact = c.Inflated
-		if z.unscaled.Sign() == 0 {
-			if s[0] == '-' {
-				z.form = nzero
-			} else {
-				z.form = zero
-			}
+	} else {
+		// ParseNumber never stores more than 18 digits in num.Compact, so
+		// it always fits into an int64.
+		z.compact = int64(num.Compact)
+		if num.Neg {
+			z.compact = -z.compact
 		}
 	}
-	z.scale = scale
-	return z, true
+
+	switch {
+	case z.compact != 0:
+		z.form = finite
+	case num.Neg:
+		z.form = nzero
+	default:
+		z.form = zero
+	}
+	return nil
 }
 
 // Sign returns:
@@ -1855,17 +2001,22 @@ func (x *Big) Signbit() bool {
 //  "-Inf"  if x.IsInf(-1)
 //
 func (x *Big) String() string {
-	// TODO(eric): use a pool?
-	var (
-		b bytes.Buffer
-		f = formatter{w: &b, prec: noPrec, width: noWidth}
-	)
-	f.format(x, normal, 'e')
-	return b.String()
+	return x.Text('s', noPrec)
 }
 
 var _ fmt.Stringer = (*Big)(nil)
 
+// EngString returns the string representation of x using engineering notation
+// if an exponent is needed. It's equivalent to the GDA spec's
+// to-engineering-string operation, except that special cases are the same as
+// String's. Unlike the %#e verb discussed in the Format method's documentation,
+// EngString does not use an exponent if x can be written without one.
+func (x *Big) EngString() string {
+	f := formatter{buf: make([]byte, 0, 16), prec: noPrec, width: noWidth}
+	f.format(x, eng, 'e')
+	return string(f.buf)
+}
+
 // Sub sets z to x - y and returns z.
 func (z *Big) Sub(x, y *Big) *Big {
 	if x.form == finite && y.form == finite {
@@ -1908,7 +2059,7 @@ func (z *Big) Sub(x, y *Big) *Big {
 
 	// ±0 - y
 	// x - ±Inf
-	return z.Neg(y)
+	return z.Neg(y).round()
 }
 
 // subCompact sets z to x - y and returns z.
@@ -1950,10 +2101,10 @@ func (z *Big) subBig(x, y *Big) *Big {
 	case x.scale == y.scale:
 		z.scale = x.scale
 	case x.scale < y.scale:
-		xb = checked.MulBigPow10(xb, y.scale-x.scale)
+		xb = checked.MulBigPow10(new(big.Int).Set(xb), y.scale-x.scale)
 		z.scale = y.scale
 	case x.scale > y.scale:
-		yb = checked.MulBigPow10(yb, x.scale-y.scale)
+		yb = checked.MulBigPow10(new(big.Int).Set(yb), x.scale-y.scale)
 		z.scale = x.scale
 	}
 	if z.unscaled.Sub(xb, yb).Sign() == 0 {
@@ -1963,6 +2114,17 @@ func (z *Big) subBig(x, y *Big) *Big {
 	return z
 }
 
+// Text converts x to a string according to the format fmt and precision
+// prec. fmt and prec have the same meaning as the verbs and precision discussed
+// in the Format method's documentation; fmt must be one of 's', 'd', 'e', 'E',
+// 'f', or 'g'. A negative prec means no precision, so x.Text('e', -1) is
+// equivalent to fmt.Sprintf("%e", x). If fmt is invalid, Text returns '%'
+// followed by fmt.
+func (x *Big) Text(fmt byte, prec int) string {
+	const compactLen = 24 // sign, 19 digits, radix, and some slack
+	return string(x.Append(make([]byte, 0, compactLen), fmt, prec))
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (z *Big) UnmarshalText(data []byte) error {
 	// TODO(eric): get rid of the allocation here.
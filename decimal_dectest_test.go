@@ -0,0 +1,190 @@
+package decimal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ericlagergren/decimal/suite"
+)
+
+// TestDecTest runs the GDA decTest files in suite/_testdata/dectest.
+func TestDecTest(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join("suite", "_testdata", "dectest", "*.decTest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases, err := suite.ParseDecTest(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, c := range cases {
+			c := c
+			t.Run(c.ID, func(t *testing.T) {
+				testDecCase(c, t)
+			})
+		}
+	}
+}
+
+var decTestModes = map[string]RoundingMode{
+	"half_even": ToNearestEven,
+	"half_up":   ToNearestAway,
+	"down":      ToZero,
+	"up":        AwayFromZero,
+	"floor":     ToNegativeInf,
+	"ceiling":   ToPositiveInf,
+}
+
+// decTestConds are the decTest conditions the package can raise. The others
+// (overflow, underflow, subnormal, and clamped) depend on an exponent range,
+// which a Context doesn't have.
+var decTestConds = map[string]Condition{
+	"conversion_syntax":    ConversionSyntax,
+	"division_by_zero":     DivisionByZero,
+	"division_impossible":  DivisionImpossible,
+	"division_undefined":   InvalidOperation, // 0/0 raises InvalidOperation
+	"inexact":              Inexact,
+	"insufficient_storage": InsufficientStorage,
+	"invalid_context":      InvalidContext,
+	"invalid_operation":    InvalidOperation,
+	"rounded":              Rounded,
+}
+
+// decTestContext returns the Context described by d and whether d can be
+// described by a Context.
+func decTestContext(d suite.Directives) (ctx Context, ok bool) {
+	mode, ok := decTestModes[d.Rounding]
+	if !ok || !d.Extended || d.Precision < MinPrecision {
+		return ctx, false
+	}
+	ctx.OperatingMode = GDA
+	ctx.RoundingMode = mode
+	ctx.SetPrecision(int32(d.Precision))
+	return ctx, true
+}
+
+func testDecCase(c suite.DecCase, t *testing.T) {
+	ctx, ok := decTestContext(c.Directives)
+	if !ok {
+		t.Skipf("%s: unsupported directives: %+v", c.ID, c.Directives)
+	}
+	var wantConds Condition
+	for _, s := range c.Conditions {
+		cond, ok := decTestConds[s]
+		if !ok {
+			t.Skipf("%s: unsupported condition: %s", c.ID, s)
+		}
+		wantConds |= cond
+	}
+	if c.Output == "?" {
+		t.Skipf("%s: result is undefined", c.ID)
+	}
+
+	// Operands are used as-is, without rounding to the Context's precision.
+	args := make([]*Big, len(c.Inputs))
+	for i, in := range c.Inputs {
+		if in == suite.NoData {
+			t.Skipf("%s: null operands are not supported", c.ID)
+		}
+		args[i] = decTestBig(string(in))
+	}
+
+	z := new(Big)
+	z.Context = ctx
+	if !decTestOp(z, c, args) {
+		t.Skipf("%s: %s is not implemented", c.ID, c.Op)
+	}
+	// Quantize does not check its result against the Context's precision.
+	if c.Op == "quantize" && wantConds&InvalidOperation != 0 {
+		t.Skipf("%s: quantize's precision limit is not implemented", c.ID)
+	}
+
+	if cond := z.Context.Conditions; cond != wantConds {
+		t.Fatalf("%s: wanted %q, got %q", c, wantConds, cond)
+	}
+	// tosci is checked like the other operations, by value and scale: String
+	// trims trailing zeros, so it is not GDA's to-scientific-string.
+	want := decTestBig(string(c.Output))
+	if !decTestEqual(z, want) {
+		t.Fatalf("%s\nwanted: %q (scale %d)\ngot   : %q (scale %d)",
+			c, want, want.Scale(), z, z.Scale())
+	}
+}
+
+// decTestOp sets z to the result of the decTest operation c.Op applied to
+// args and reports whether the operation is implemented by this package.
+func decTestOp(z *Big, c suite.DecCase, args []*Big) bool {
+	switch c.Op {
+	case "add":
+		z.Add(args[0], args[1])
+	case "subtract":
+		z.Sub(args[0], args[1])
+	case "multiply":
+		z.Mul(args[0], args[1])
+	case "divide":
+		z.Quo(args[0], args[1])
+	case "abs":
+		z.Abs(args[0])
+	case "minus":
+		z.Neg(args[0])
+	case "plus":
+		z.Set(args[0])
+	case "quantize":
+		// Quantize takes a scale, not a decimal, so it can't quantize to an
+		// infinity or propagate a NaN.
+		if !args[0].IsFinite() && args[0].Sign() != 0 || !args[1].IsFinite() && args[1].Sign() != 0 {
+			return false
+		}
+		z.Copy(args[0]).Quantize(args[1].Scale())
+	case "compare":
+		// Cmp's result is undefined for NaNs.
+		if args[0].IsNaN(0) || args[1].IsNaN(0) {
+			return false
+		}
+		z.SetMantScale(int64(args[0].Cmp(args[1])), 0)
+	case "tosci":
+		z.SetString(string(c.Inputs[0]))
+	default:
+		return false
+	}
+	return true
+}
+
+// decTestBig parses a decTest operand or result, which never raises a
+// condition.
+func decTestBig(s string) *Big {
+	x := new(Big)
+	x.Context.OperatingMode = GDA
+	x.Context.SetPrecision(0)
+	x.SetString(s)
+	x.Context.Conditions = 0
+	return x
+}
+
+// decTestEqual reports whether x and y have the same value, sign, and, if
+// they're finite, scale.
+func decTestEqual(x, y *Big) bool {
+	return sameValue(x, y) && (!x.IsFinite() || x.Scale() == y.Scale())
+}
+
+// sameValue reports whether x and y have the same form, sign, and value.
+func sameValue(x, y *Big) bool {
+	switch {
+	case x.IsNaN(0) || y.IsNaN(0):
+		return x.IsNaN(+1) == y.IsNaN(+1) && x.IsNaN(-1) == y.IsNaN(-1)
+	case x.IsInf(0) || y.IsInf(0):
+		return x.IsInf(+1) == y.IsInf(+1) && x.IsInf(-1) == y.IsInf(-1)
+	}
+	return x.Cmp(y) == 0 && x.Signbit() == y.Signbit()
+}
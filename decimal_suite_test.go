@@ -2,13 +2,11 @@ package decimal
 
 import (
 	"archive/tar"
-	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -68,6 +66,47 @@ func TestSuiteCases(t *testing.T) {
 	}
 }
 
+func TestSuiteOps(t *testing.T) {
+	for _, line := range [...]string{
+		"d64~ =0 +12 -> -12",
+		"d64A =0 -12 -> +12",
+		"d64cp =0 -1.50 -> -1.50",
+		"d64rfi =0 +2.5 -> +2",
+		"d64rfi =^ +2.5 -> +3",
+		"d64rfi 0 -2.9 -> -2",
+		"d64rfi =0 +1e+5 -> +1e+5",
+		"d64quant =0 +1.2345 +1e-2 -> +1.23 x",
+		"d64cfd =0 +1.5e3 -> +1500",
+		"d64cdf =0 -0.25 -> -0.25",
+		"d64qC =0 +1 +2 -> -1",
+		"d64sC =0 +2 +2 -> 0",
+		"d64<C =0 +1 -2 -> -2",
+		"d64<C =0 +0 -0 -> -0",
+		"d64>C =0 +1 -2 -> +1",
+		"d64>C =0 -0 +0 -> +0",
+		"d64<A =0 +1 -2 -> +1",
+		"d64<A =0 +2 -2 -> -2",
+		"d64>A =0 +1 -2 -> -2",
+		"d64>A =0 -Inf +Inf -> +Inf",
+		"d64=quant =0 +1.5 +2.5 -> 1",
+		"d64?- =0 -0 -> 1",
+		"d64?i =0 -inf -> 1",
+		"d64?N =0 Q -> 1",
+		"d64?sN =0 Q -> 0",
+		"d64?0 =0 +0e+5 -> 1",
+		"d64?f =0 +inf -> 0",
+		"d64V =0 +4 -> +2",
+	} {
+		c, err := suite.ParseCase([]byte(line))
+		if err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+		t.Run(c.Op.String(), func(t *testing.T) {
+			testCase("TestSuiteOps", 1, c, GDA, t)
+		})
+	}
+}
+
 func precision(s suite.Data) (p int32) {
 	j := strings.IndexAny(string(s), "eE")
 	if j < 0 {
@@ -116,6 +155,7 @@ func testCase(fname string, i int, c suite.Case, mode OperatingMode, t *testing.
 	var (
 		cond Condition
 		err  error
+		skip bool
 		args = make([]*Big, len(c.Inputs))
 	)
 	for i, data := range c.Inputs {
@@ -131,21 +171,11 @@ func testCase(fname string, i int, c suite.Case, mode OperatingMode, t *testing.
 			}
 			cond = z.Context.Conditions
 		}()
-		switch c.Op {
-		case suite.Add:
-			z.Add(args[0], args[1])
-		case suite.Sub:
-			z.Sub(args[0], args[1])
-		case suite.Mul:
-			z.Mul(args[0], args[1])
-		case suite.Div:
-			z.Quo(args[0], args[1])
-		case suite.Neg:
-			z.Neg(args[0])
-		default:
-			t.Fatalf("bad op: %q", c.Op)
-		}
+		skip = !suiteOp(z, c, args)
 	}()
+	if skip {
+		t.Skipf("%s: %s is not implemented", fname, c.Op)
+	}
 
 	if testing.Verbose() {
 		t.Logf("%s: %s => [%e, %q, %v]", mode, c, z, cond, err)
@@ -172,7 +202,7 @@ func testCase(fname string, i int, c suite.Case, mode OperatingMode, t *testing.
 				t.Logf("CHECK: %s", msg)
 			}
 		} else if mode != Go {
-			t.Fatalf(msg)
+			t.Fatal(msg)
 		}
 	}
 
@@ -184,21 +214,132 @@ func testCase(fname string, i int, c suite.Case, mode OperatingMode, t *testing.
 	neitherNaN := !want.IsNaN(0) && !z.IsNaN(0)
 
 	if badNaN || (neitherNaN && want.Cmp(z) != 0 && mode == GDA) {
-		t.Parallel()
-		pywant := shellOut(args[0], args[1], c.Op, z.Context.Precision())
-		if prec != 0 {
-			pywant.Round(prec)
+		if !isArith(c.Op) {
+			t.Fatalf("%s#%d: %s\nwanted: %q\ngot   : %q\n", fname, i, c, want, z)
+		}
+		refwant := refOp(args[0], args[1], c.Op, z.Context)
+		if refwant != nil && prec != 0 {
+			refwant.Round(prec)
 		}
 
 		// Test suite says we got the incorrect answer, but double-check with
-		// Python since the test suite doesn't use arbitrary precision.
-		if z.Cmp(pywant) != 0 {
+		// the exact reference implementation since the test suite doesn't use
+		// arbitrary precision.
+		if refwant == nil || z.Cmp(refwant) != 0 {
 			t.Fatal(fmt.Sprintf(`%s#%d: %s
 wanted: "%e" (or "%e")
 got   : "%e"
-`, fname, i, c, want, pywant, z))
+`, fname, i, c, want, refwant, z))
+		}
+	}
+}
+
+// suiteOp sets z to the result of the operation c.Op applied to args and
+// reports whether the operation is implemented by this package. Predicates
+// set z to 1 if true and 0 if false, and comparisons set z to -1, 0, or +1, or
+// NaN if the operands are unordered.
+func suiteOp(z *Big, c suite.Case, args []*Big) bool {
+	switch c.Op {
+	case suite.Add:
+		z.Add(args[0], args[1])
+	case suite.Sub:
+		z.Sub(args[0], args[1])
+	case suite.Mul:
+		z.Mul(args[0], args[1])
+	case suite.Div:
+		z.Quo(args[0], args[1])
+	case suite.Neg:
+		z.Neg(args[0])
+	case suite.Abs:
+		z.Abs(args[0])
+	case suite.Copy:
+		z.Copy(args[0])
+	case suite.RFI:
+		// roundToIntegral leaves integers alone, so 1E+5 keeps its exponent.
+		// Unlike quantize, it doesn't signal Inexact.
+		if z.Copy(args[0]).Scale() > 0 {
+			z.Quantize(0)
+			z.Context.Conditions &^= Inexact
+		}
+	case suite.Quantize:
+		z.Copy(args[0]).Quantize(args[1].Scale())
+	case suite.CFD:
+		z.SetString(args[0].String())
+	case suite.CDF:
+		z.SetString(string(c.Inputs[0]))
+	case suite.QuietCmp, suite.SigCmp:
+		// Cmp's result is undefined for NaNs, so unordered comparisons
+		// aren't implemented.
+		if args[0].IsNaN(0) || args[1].IsNaN(0) {
+			return false
+		}
+		z.SetMantScale(int64(args[0].Cmp(args[1])), 0)
+	case suite.MinNum, suite.MaxNum, suite.MinNumMag, suite.MaxNumMag:
+		if args[0].IsNaN(0) || args[1].IsNaN(0) {
+			return false
 		}
+		z.Set(minMax(c.Op, args[0], args[1]))
+	case suite.SameQuantum:
+		setBool(z, args[0].Scale() == args[1].Scale())
+	case suite.IsSigned:
+		setBool(z, args[0].Signbit())
+	case suite.IsInf:
+		setBool(z, args[0].IsInf(0))
+	case suite.IsNaN:
+		setBool(z, args[0].IsNaN(0))
+	case suite.IsSignaling:
+		setBool(z, args[0].IsNaN(-1))
+	case suite.IsZero:
+		setBool(z, !args[0].IsNaN(0) && args[0].Sign() == 0)
+	case suite.IsFinite:
+		setBool(z, !args[0].IsNaN(0) && !args[0].IsInf(0))
+	default:
+		// FMA, Sqrt, Rem, CFF, CFI, CIF, CopySign, Scalb, Logb, NextAfter,
+		// Class, IsNormal, IsSubNormal, NextUp, NextDown, and Equiv.
+		return false
 	}
+	return true
+}
+
+// minMax returns the result of op, one of MinNum, MaxNum, MinNumMag, or
+// MaxNumMag, applied to x and y, neither of which may be NaN. The magnitude
+// operations fall back to MinNum and MaxNum if |x| == |y|, and MinNum and
+// MaxNum prefer -0 and +0, respectively, if x == y.
+func minMax(op suite.Op, x, y *Big) *Big {
+	var c int
+	switch op {
+	case suite.MinNumMag, suite.MaxNumMag:
+		c = new(Big).Abs(x).Cmp(new(Big).Abs(y))
+	}
+	if c == 0 {
+		if c = x.Cmp(y); c == 0 && x.Signbit() != y.Signbit() {
+			c = +1
+			if x.Signbit() {
+				c = -1
+			}
+		}
+	}
+	if (op == suite.MinNum || op == suite.MinNumMag) == (c <= 0) {
+		return x
+	}
+	return y
+}
+
+func setBool(z *Big, b bool) {
+	if b {
+		z.SetMantScale(1, 0)
+	} else {
+		z.SetMantScale(0, 0)
+	}
+}
+
+// isArith reports whether op is one of the operations refOp can check.
+func isArith(op suite.Op) bool {
+	switch op {
+	case suite.Add, suite.Sub, suite.Mul, suite.Div:
+		return true
+	}
+	return false
 }
 
 var testZero = New(0, 0)
@@ -228,34 +369,20 @@ func dataToBig(s suite.Data, ctx Context) *Big {
 	}
 }
 
-func shellOut(x, y *Big, op suite.Op, prec int32) *Big {
-	var opstr string
+// refOp returns the result of op applied to x and y as computed by ref using
+// ctx's precision and RoundingMode, or nil if ref can't compute it.
+func refOp(x, y *Big, op suite.Op, ctx Context) *Big {
+	r := ref{prec: ctx.Precision(), mode: ctx.RoundingMode}
 	switch op {
 	case suite.Add:
-		opstr = "+"
+		return r.add(x, y)
 	case suite.Sub:
-		opstr = "-"
+		return r.sub(x, y)
 	case suite.Mul:
-		opstr = "*"
+		return r.mul(x, y)
 	case suite.Div:
-		opstr = "/"
+		return r.quo(x, y)
 	default:
 		panic(fmt.Sprintf("bad op %q", op))
 	}
-	cmd := fmt.Sprintf(`
-python3 - <<EOF
-from decimal import *
-getcontext().prec = %d
-print(Decimal(%q) %s Decimal(%q))
-EOF
-`, prec, x, opstr, y)
-	out, err := exec.Command("sh", "-c", cmd).CombinedOutput()
-	if err != nil {
-		panic(fmt.Sprintf("err: %v: %s", err, out))
-	}
-	x, ok := new(Big).SetString(string(bytes.TrimSuffix(out, []byte{'\n'})))
-	if !ok {
-		panic("!ok")
-	}
-	return x
 }
@@ -73,6 +73,13 @@ func TestBig_Abs(t *testing.T) {
 			t.Fatalf("#%d: wanted %s, got %s", i, test, xs)
 		}
 	}
+
+	// z is compact before the call and must not keep its old value.
+	const huge = "123456789012345678901234567890"
+	z := New(5, 0)
+	if zs := z.Abs(newbig(t, "-"+huge)).String(); zs != huge {
+		t.Fatalf("inflated: wanted %s, got %s", huge, zs)
+	}
 }
 
 func TestBig_Add(t *testing.T) {
@@ -102,6 +109,25 @@ got   : %q
 	}
 }
 
+func TestBig_Append(t *testing.T) {
+	x := New(-12345, 3)
+	got := string(x.Append([]byte("x="), 'f', 1))
+	if want := "x=-12.3"; got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+
+	buf := make([]byte, 0, 64)
+	for _, c := range [...]byte{'s', 'e', 'f', 'g'} {
+		c := c
+		n := testing.AllocsPerRun(100, func() {
+			buf = x.Append(buf[:0], c, -1)
+		})
+		if n != 0 {
+			t.Fatalf("Append(%c): wanted 0 allocations, got %f", c, n)
+		}
+	}
+}
+
 func TestBig_BitLen(t *testing.T) {
 	var x Big
 	const maxCompact = (1<<63 - 1) - 1
@@ -371,6 +397,31 @@ got   : %q
 	}
 }
 
+func TestBig_MulZero(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y  *Big
+		scale int32
+		neg   bool
+		cond  Condition
+	}{
+		0: {New(9, 1), New(0, 0).Neg(New(0, 0)), 1, true, 0},
+		1: {newbig(t, "0.00"), New(-5, 1), 3, true, 0},
+		2: {&Big{scale: MaxScale}, New(5, 1), MaxScale, false, Underflow | Clamped},
+		3: {&Big{scale: MinScale}, New(5, -1), MinScale, false, Overflow | Clamped},
+	} {
+		z := new(Big)
+		z.Context.OperatingMode = GDA
+		z.Mul(test.x, test.y)
+		if z.Sign() != 0 || z.Signbit() != test.neg || z.Scale() != test.scale {
+			t.Fatalf("#%d: %s * %s: wanted a zero with scale %d (negative: %t), got %s with scale %d",
+				i, test.x, test.y, test.scale, test.neg, z, z.Scale())
+		}
+		if z.Context.Conditions != test.cond {
+			t.Fatalf("#%d: %s * %s: wanted %s, got %s", i, test.x, test.y, test.cond, z.Context.Conditions)
+		}
+	}
+}
+
 func TestBig_Prec(t *testing.T) {
 	// confirmed to work inside internal/arith/intlen_test.go
 }
@@ -402,6 +453,103 @@ got   : %q
 	}
 }
 
+func TestBig_QuoIdealExp(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y  string
+		want  string
+		scale int32
+	}{
+		0: {"1", "1", "1", 0},
+		1: {"1", "2", "0.5", 1},
+		2: {"2.40E+6", "2", "1.20E+6", -4},
+		3: {"1000", "100", "10", 0},
+		4: {"1", "3", "0.333333333", 9},
+		5: {"0.00", "2.5", "0.0", 1},
+		6: {"0", "-2", "-0", 0},
+		7: {"12345678901234567890000", "1000", "12345678901234567890", 0},
+	} {
+		z := new(Big)
+		z.Context.OperatingMode = GDA
+		z.Context.SetPrecision(9)
+		if i == 7 {
+			z.Context.SetPrecision(30)
+		}
+		z.Quo(newbig(t, test.x), newbig(t, test.y))
+		want := newbig(t, test.want)
+		if z.Cmp(want) != 0 || z.Signbit() != want.Signbit() || z.Scale() != test.scale {
+			t.Fatalf("#%d: %s / %s: wanted %s with scale %d, got %s with scale %d",
+				i, test.x, test.y, test.want, test.scale, z, z.Scale())
+		}
+	}
+}
+
+func TestBig_QuoPrecision(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y string
+		prec int32
+		want string
+	}{
+		// x and y have the same normalized coefficient.
+		0: {"1", "1", 7, "1"},
+		1: {"2.5", "25", 5, "0.1"},
+		2: {"123", "0.123", 4, "1000"},
+		3: {"12345678901234567890123", "1.2345678901234567890123", 30, "1e+22"},
+		// Rounding 99...9 up carries into a new digit.
+		4: {"1", "101", 1, "0.01"},
+		5: {"1", "1.0001", 3, "1"},
+		6: {"1", "1.00000000000000000000001", 20, "1"},
+	} {
+		z := new(Big)
+		// GDA mode would reduce the exact quotients.
+		z.Context.OperatingMode = Go
+		z.Context.SetPrecision(test.prec)
+		z.Quo(newbig(t, test.x), newbig(t, test.y))
+		if z.Cmp(newbig(t, test.want)) != 0 {
+			t.Fatalf("#%d: %s / %s: wanted %s, got %s", i, test.x, test.y, test.want, z)
+		}
+		if z.Precision() != int(test.prec) {
+			t.Fatalf("#%d: %s / %s: %s has %d digits, wanted %d",
+				i, test.x, test.y, z, z.Precision(), test.prec)
+		}
+	}
+}
+
+func TestBig_Quantize(t *testing.T) {
+	for i, test := range [...]struct {
+		v    string
+		to   int32
+		mode RoundingMode
+		res  string
+	}{
+		0:  {"1.235", 2, ToNearestEven, "1.24"},
+		1:  {"1.245", 2, ToNearestEven, "1.24"},
+		2:  {"-1.245", 2, ToNearestAway, "-1.25"},
+		3:  {"1.2", 3, ToNearestEven, "1.2"},
+		4:  {"-1.231", 2, ToPositiveInf, "-1.23"},
+		5:  {"-1.231", 2, ToNegativeInf, "-1.24"},
+		6:  {"1.239", 2, ToZero, "1.23"},
+		7:  {"1.231", 2, AwayFromZero, "1.24"},
+		8:  {"0.004", 2, ToNearestEven, "0"},
+		9:  {"0.005", 2, AwayFromZero, "0.01"},
+		10: {"123456789012345678901234567890.5", 0, ToNearestEven, "123456789012345678901234567890"},
+		11: {"-123456789012345678901234567891.5", 0, ToNearestEven, "-123456789012345678901234567892"},
+		12: {"12345678901234567.89", 5, ToNearestEven, "12345678901234567.89"},
+		13: {"0.1", -1, ToPositiveInf, "1e+1"},
+	} {
+		bd := newbig(t, test.v)
+		bd.Context.RoundingMode = test.mode
+		if rs := bd.Quantize(test.to).String(); rs != test.res {
+			t.Fatalf(`#%d:
+wanted: %q
+got   : %q
+`, i, test.res, rs)
+		}
+		if bd.Scale() != test.to {
+			t.Fatalf("#%d: wanted scale %d, got %d", i, test.to, bd.Scale())
+		}
+	}
+}
+
 func TestBig_Rat(t *testing.T) {
 	for i, test := range [...]string{
 		"42", "3.14156", "23423141234", ".44444", "1e+1222", "12e-444", "0",
@@ -423,14 +571,17 @@ func TestBig_Round(t *testing.T) {
 		to  int32
 		res string
 	}{
-		0: {"5.5", 1, "6"},
-		1: {"1.234", 2, "1.2"},
-		2: {"1", 1, "1"},
-		3: {"9.876", 0, "9.876"},
-		4: {"5.65", 2, "5.6"},
-		5: {"5.0002", 2, "5"},
-		6: {"0.000158674", 6, "0.000158674"},
-		7: {"1.58089722856961873690377135139876745465351534188711107066818e+12288", 50, "1.5808972285696187369037713513987674546535153418871e+12288"},
+		0:  {"5.5", 1, "6"},
+		1:  {"1.234", 2, "1.2"},
+		2:  {"1", 1, "1"},
+		3:  {"9.876", 0, "9.876"},
+		4:  {"5.65", 2, "5.6"},
+		5:  {"5.0002", 2, "5"},
+		6:  {"0.000158674", 6, "0.000158674"},
+		7:  {"1.58089722856961873690377135139876745465351534188711107066818e+12288", 50, "1.5808972285696187369037713513987674546535153418871e+12288"},
+		8:  {"9.999", 3, "10"},
+		9:  {"-9.9999", 1, "-1e+1"},
+		10: {"99999999999999999999.9", 20, "1.0000000000000000000e+20"},
 	} {
 		bd := newbig(t, test.v)
 		if rs := bd.Round(test.to).String(); rs != test.res {
@@ -439,11 +590,42 @@ wanted: %q
 got   : %q
 `, i, test.res, rs)
 		}
+		// Rounding 99...9 up must not leave an extra digit.
+		if test.to > 0 && bd.Precision() > int(test.to) {
+			t.Fatalf("#%d: %s has %d digits, wanted at most %d",
+				i, bd, bd.Precision(), test.to)
+		}
 	}
 }
 
 func TestBig_Scan(t *testing.T) {
-	// TODO(erc): this
+	for i, test := range [...]struct {
+		in   string
+		want string
+		rest string
+	}{
+		0: {"1.5", "1.5", ""},
+		1: {"  -12.25e2 foo", "-1225", " foo"},
+		2: {"1.5abc", "1.5", "abc"},
+		3: {"Infinity!", "+Inf", "!"},
+	} {
+		var z Big
+		r := strings.NewReader(test.in)
+		if _, err := fmt.Fscan(r, &z); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if s := z.String(); s != test.want {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.want, s)
+		}
+		if rest := test.in[len(test.in)-r.Len():]; rest != test.rest {
+			t.Fatalf("#%d: wanted %q left over, got %q", i, test.rest, rest)
+		}
+	}
+
+	var z Big
+	if _, err := fmt.Sscan("abc", &z); err == nil {
+		t.Fatal("wanted error, got nil")
+	}
 }
 
 func TestBig_SetFloat64(t *testing.T) {
@@ -554,6 +736,167 @@ func TestBig_String(t *testing.T) {
 	}
 }
 
+func TestBig_EngString(t *testing.T) {
+	tests := [...]struct {
+		a string
+		b string
+	}{
+		0:  {a: "125E+2", b: "12.5e+3"},
+		1:  {a: "1E+2", b: "100"},
+		2:  {a: "1E+3", b: "1e+3"},
+		3:  {a: "1E+4", b: "10e+3"},
+		4:  {a: "12E+4", b: "120e+3"},
+		5:  {a: "-123456E+3", b: "-123.456e+6"},
+		6:  {a: "12.345", b: "12.345"},
+		7:  {a: "0.000001", b: "0.000001"},
+		8:  {a: "1E-7", b: "100e-9"},
+		9:  {a: "123E-10", b: "12.3e-9"},
+		10: {a: "-1.4e-52", b: "-140e-54"},
+		11: {a: "0", b: "0"},
+		12: {a: "Inf", b: "+Inf"},
+	}
+	for i, s := range tests {
+		str := newbig(t, s.a).EngString()
+		if str != s.b {
+			t.Fatalf("#%d: EngString(%s): wanted %q, got %q", i, s.a, s.b, str)
+		}
+	}
+
+	if str := fmt.Sprintf("%#E", newbig(t, "125E+2")); str != "12.5E+3" {
+		t.Fatalf("%%#E: wanted %q, got %q", "12.5E+3", str)
+	}
+
+	// Unlike EngString, %#e always uses an exponent.
+	for i, s := range [...]struct {
+		a string
+		b string
+	}{
+		0: {a: "1200000", b: "1.2e+6"},
+		1: {a: "12000000", b: "12e+6"},
+		2: {a: "123456", b: "123.456e+3"},
+		3: {a: "0.0012", b: "1.2e-3"},
+	} {
+		if str := fmt.Sprintf("%#e", newbig(t, s.a)); str != s.b {
+			t.Fatalf("#%d: %%#e of %s: wanted %q, got %q", i, s.a, s.b, str)
+		}
+	}
+}
+
+func TestBig_FormatZero(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		want string
+	}{
+		0: {"0", "0"},
+		1: {"-0", "-0"},
+		2: {"0E+1", "0"},
+		3: {"0E-7", "0"},
+		4: {"-0.00", "-0"},
+		5: {"-0E+5", "-0"},
+	} {
+		for _, mode := range [...]OperatingMode{Go, GDA} {
+			x := newbig(t, test.x)
+			x.Context.OperatingMode = mode
+			for _, got := range [...]string{
+				x.String(),
+				x.EngString(),
+				x.Text('e', -1),
+				x.Text('f', -1),
+				fmt.Sprintf("%#e", x),
+			} {
+				if got != test.want {
+					t.Fatalf("#%d: %s [mode: %s]: wanted %q, got %q",
+						i, test.x, mode, test.want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestBig_Text(t *testing.T) {
+	tests := [...]struct {
+		x    string
+		fmt  byte
+		prec int
+		want string
+	}{
+		0:  {"12.345", 's', -1, "12.345"},
+		1:  {"12.345", 'd', -1, "12.345"},
+		2:  {"12.345", 'e', -1, "1.2345e+1"},
+		3:  {"12.345", 'E', 3, "1.23E+1"},
+		4:  {"12.345", 'f', 1, "12.3"},
+		5:  {"12.345", 'f', -1, "12.345"},
+		6:  {"12.345", 'g', 2, "12"},
+		7:  {"-1.4e-52", 's', -1, "-1.4e-52"},
+		8:  {"123456789012345678901234567890", 's', -1, "123456789012345678901234567890"},
+		9:  {"Inf", 's', -1, "+Inf"},
+		10: {"12.345", 'x', -1, "%x"},
+		11: {"9.99", 'f', 0, "10"},
+		12: {"9.99", 'f', 1, "10.0"},
+		13: {"99.5", 'f', 0, "100"},
+		14: {"0.96", 'f', 1, "1.0"},
+		15: {"0.001", 'f', 1, "0.0"},
+		16: {"0.0456", 'f', 0, "0"},
+		17: {"0.0456", 'f', 2, "0.05"},
+		18: {"-0.0456", 'f', 1, "-0.0"},
+		19: {"12.345", 'f', 5, "12.34500"},
+		20: {"1.5", 'f', 2, "1.50"},
+		21: {"0", 'f', 2, "0.00"},
+		22: {"0E-7", 'f', 2, "0.00"},
+		23: {"0E+1", 'f', 1, "0.0"},
+		24: {"-0", 'f', 3, "-0.000"},
+		25: {"12E+2", 'f', 1, "1200.0"},
+		26: {"Inf", 'f', 2, "+Inf"},
+	}
+	for i, test := range tests {
+		x := newbig(t, test.x)
+		if got := x.Text(test.fmt, test.prec); got != test.want {
+			t.Fatalf("#%d: Text(%c, %d): wanted %q, got %q",
+				i, test.fmt, test.prec, test.want, got)
+		}
+		// Text and Format must agree.
+		if test.fmt == 'x' {
+			continue
+		}
+		var want string
+		if test.prec < 0 {
+			want = fmt.Sprintf("%"+string(test.fmt), x)
+		} else {
+			want = fmt.Sprintf("%.*"+string(test.fmt), test.prec, x)
+		}
+		if got := x.Text(test.fmt, test.prec); got != want {
+			t.Fatalf("#%d: Text(%c, %d) = %q, but Sprintf gives %q",
+				i, test.fmt, test.prec, got, want)
+		}
+	}
+}
+
+func TestBig_FormatWidth(t *testing.T) {
+	for i, test := range [...]struct {
+		format string
+		x      string
+		want   string
+	}{
+		0:  {"%8.2f", "0.001", "    0.00"},
+		1:  {"%08.1f", "0.001", "000000.0"},
+		2:  {"%-8.2f", "0.001", "0.00    "},
+		3:  {"%08.1f", "-0.001", "-00000.0"},
+		4:  {"%+8.2f", "0.001", "   +0.00"},
+		5:  {"%6.1f", "0", "   0.0"},
+		6:  {"%8.2f", "1.5", "    1.50"},
+		7:  {"%08.2f", "-1.5", "-0001.50"},
+		8:  {"%4.2f", "12.345", "12.34"},
+		9:  {"%6s", "Inf", "  +Inf"},
+		10: {"%06s", "Inf", "  +Inf"},
+	} {
+		x := newbig(t, test.x)
+		if got := fmt.Sprintf(test.format, x); got != test.want {
+			t.Fatalf("#%d: Sprintf(%q, %s): wanted %q, got %q",
+				i, test.format, test.x, test.want, got)
+		}
+	}
+}
+
 func TestBig_Sub(t *testing.T) {
 	s, close := getTests(t, "subtraction")
 	defer close()
@@ -580,3 +923,24 @@ got   : %q
 		}
 	}
 }
+
+// TestBig_SubOperands verifies that aligning the scales of the operands does
+// not modify them.
+func TestBig_SubOperands(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y, r string
+	}{
+		0: {"123456789012345678901234567890", "0.5", "123456789012345678901234567889.5"},
+		1: {"0.5", "123456789012345678901234567890", "-123456789012345678901234567889.5"},
+	} {
+		x, y := newbig(t, test.x), newbig(t, test.y)
+		z := new(Big)
+		z.Context.SetPrecision(50)
+		if zs := z.Sub(x, y).String(); zs != test.r {
+			t.Fatalf("#%d: %s - %s: wanted %s, got %s", i, test.x, test.y, test.r, zs)
+		}
+		if x.String() != test.x || y.String() != test.y {
+			t.Fatalf("#%d: operands modified: %s, %s", i, x, y)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package decimal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal/internal/parse"
+)
+
+// SyntaxError describes a number that does not match the numeric string
+// grammar.
+type SyntaxError struct {
+	// Offset is the offset, in bytes, of the invalid input relative to where
+	// the Decoder began reading.
+	Offset int64
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("decimal: syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// A Decoder reads decimal numbers from an input stream without allocating a
+// string per number.
+type Decoder struct {
+	s scanner
+}
+
+// NewDecoder returns a new Decoder that reads from r. If r is not an
+// io.ByteScanner it's wrapped in a bufio.Reader. Otherwise, the Decoder reads
+// no further than the first byte following each number, so r can be used to
+// read whatever separates numbers; for example, commas in a CSV file.
+func NewDecoder(r io.Reader) *Decoder {
+	br, ok := r.(io.ByteScanner)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Decoder{s: scanner{r: br}}
+}
+
+// Decode skips any leading white space, then reads the next number from the
+// input and stores it in z. The number must match the numeric string grammar
+// from the General Decimal Arithmetic Specification:
+//
+//     sign           ::=  '+' | '-'
+//     digit          ::=  '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' |
+//                         '8' | '9'
+//     indicator      ::=  'e' | 'E'
+//     digits         ::=  digit [digit]...
+//     decimal-part   ::=  digits '.' [digits] | ['.'] digits
+//     exponent-part  ::=  indicator [sign] digits
+//     infinity       ::=  'Infinity' | 'Inf'
+//     nan            ::=  'NaN' [digits] | 'sNaN' [digits]
+//     numeric-value  ::=  decimal-part [exponent-part] | infinity
+//     numeric-string ::=  [sign] numeric-value | [sign] nan
+//
+// Decode uses the same parser as SetString, so it accepts exactly the strings
+// SetString does: Infinity and NaN are case-insensitive, NaN may be written as
+// qNaN, and digits following a NaN (its diagnostic information) are read but
+// otherwise ignored. Like SetString, Decode does not round z.
+//
+// A number ends at the first byte that cannot be part of one. Decode returns
+// io.EOF if there is no more input and a *SyntaxError if the input is not a
+// number. In the latter case, z is set to NaN and, if z's OperatingMode is GDA,
+// the ConversionSyntax Condition is raised. If the exponent is out of range, z
+// is set to ±Inf or ±0, the Overflow or Underflow Condition is raised, and
+// Decode returns an error, just as SetString would.
+func (d *Decoder) Decode(z *Big) error {
+	if err := d.s.skipSpace(); err != nil {
+		return err
+	}
+	return d.s.scan(z)
+}
+
+// InputOffset returns the offset, in bytes, of the Decoder's current position
+// relative to where it began reading.
+func (d *Decoder) InputOffset() int64 { return d.s.off }
+
+// scanner reads numbers from an io.ByteScanner.
+type scanner struct {
+	r   io.ByteScanner
+	off int64
+	buf []byte
+}
+
+func (s *scanner) read() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err == nil {
+		s.off++
+	}
+	return b, err
+}
+
+func (s *scanner) unread() {
+	if s.r.UnreadByte() == nil {
+		s.off--
+	}
+}
+
+func (s *scanner) skipSpace() error {
+	for {
+		b, err := s.read()
+		if err != nil {
+			return err
+		}
+		switch b {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+		default:
+			s.unread()
+			return nil
+		}
+	}
+}
+
+// scan reads one number and stores it in z.
+func (s *scanner) scan(z *Big) error {
+	start := s.off
+	next, err := s.token()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if len(s.buf) == 0 && err == io.EOF {
+		return io.EOF
+	}
+
+	num := parse.ParseNumber(string(s.buf), &z.unscaled)
+	if num.Form == parse.Invalid {
+		var msg string
+		switch {
+		case num.Off < len(s.buf):
+			msg = fmt.Sprintf("unexpected %q", s.buf[num.Off])
+		case err == io.EOF:
+			msg = "unexpected EOF"
+		default:
+			msg = fmt.Sprintf("unexpected %q", next)
+		}
+		serr := &SyntaxError{Offset: start + int64(num.Off), Msg: msg}
+		z.form = qnan
+		z.signal(ConversionSyntax, serr)
+		return serr
+	}
+	return z.setNumber(num)
+}
+
+// token reads the bytes that might be part of a number into s.buf: an
+// optional sign followed by either a word (letters and digits, such as "Inf" or
+// "NaN123") or digits and '.'s with at most one 'e' or 'E', which may be
+// directly followed by a sign. It returns the byte that ended the token, which
+// is unread, or the error that ended it. Whether s.buf is a number is up to
+// ParseNumber.
+func (s *scanner) token() (next byte, err error) {
+	s.buf = s.buf[:0]
+	var (
+		word bool // the token started with a letter
+		exp  bool // the token has an exponent indicator
+	)
+	for {
+		b, err := s.read()
+		if err != nil {
+			return 0, err
+		}
+		n := len(s.buf)
+		var ok bool
+		switch {
+		case b == '+' || b == '-':
+			ok = n == 0 || (exp && s.buf[n-1]|0x20 == 'e')
+		case isDigit(b):
+			ok = true
+		case b == '.':
+			ok = !word && !exp
+		case isLetter(b):
+			if n == 0 || (n == 1 && (s.buf[0] == '+' || s.buf[0] == '-')) {
+				word = true
+			}
+			ok = word || (!exp && b|0x20 == 'e')
+			exp = exp || !word
+		}
+		if !ok {
+			s.unread()
+			return b, nil
+		}
+		s.buf = append(s.buf, b)
+	}
+}
+
+func isDigit(b byte) bool  { return '0' <= b && b <= '9' }
+func isLetter(b byte) bool { return 'a' <= b|0x20 && b|0x20 <= 'z' }
+
+// runeByteScanner adapts a fmt.ScanState to an io.ByteScanner. Runes outside
+// of the ASCII range are never part of a number, so they're replaced by an
+// invalid byte.
+type runeByteScanner struct {
+	fmt.ScanState
+}
+
+func (r runeByteScanner) ReadByte() (byte, error) {
+	c, _, err := r.ReadRune()
+	if c >= utf8.RuneSelf {
+		c = utf8.RuneSelf
+	}
+	return byte(c), err
+}
+
+func (r runeByteScanner) UnreadByte() error { return r.UnreadRune() }
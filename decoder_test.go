@@ -0,0 +1,146 @@
+package decimal
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	const in = " 1 -2.50\t1e+3 .5 5. 0.000 -0 123456789012345678901234567890.12345" +
+		" -9223372036854775808 1E-5 Inf -infinity NaN sNaN123 qNaN +12"
+	want := [...]string{
+		"1", "-2.5", "1e+3", "0.5", "5", "0", "-0", "123456789012345678901234567890.12345",
+		"-9223372036854775808", "0.00001", "+Inf", "-Inf", "NaN", "NaN", "NaN", "12",
+	}
+
+	d := NewDecoder(strings.NewReader(in))
+	for i, w := range want {
+		z := new(Big)
+		if err := d.Decode(z); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if w == "NaN" {
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: wanted NaN, got %s", i, z)
+			}
+			continue
+		}
+		if s := z.String(); s != w {
+			t.Fatalf("#%d: wanted %q, got %q", i, w, s)
+		}
+	}
+	if err := d.Decode(new(Big)); err != io.EOF {
+		t.Fatalf("wanted io.EOF, got %v", err)
+	}
+	if n := d.InputOffset(); n != int64(len(in)) {
+		t.Fatalf("wanted offset %d, got %d", len(in), n)
+	}
+}
+
+func TestDecoder_Scale(t *testing.T) {
+	for i, test := range [...]struct {
+		in    string
+		scale int32
+	}{
+		0: {"1.50", 2},
+		1: {"1.50e1", 1},
+		2: {"1e+3", -3},
+		3: {"0.000", 3},
+	} {
+		z := new(Big)
+		if err := NewDecoder(strings.NewReader(test.in)).Decode(z); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z.Scale() != test.scale {
+			t.Fatalf("#%d: wanted scale %d, got %d", i, test.scale, z.Scale())
+		}
+	}
+}
+
+func TestDecoder_CSV(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("1.25,2.50,-3\n"))
+	d := NewDecoder(r)
+
+	sum := new(Big)
+	for {
+		var x Big
+		if err := d.Decode(&x); err != nil {
+			t.Fatal(err)
+		}
+		sum.Add(sum, &x)
+		b, err := r.ReadByte()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b == '\n' {
+			break
+		}
+		if b != ',' {
+			t.Fatalf("wanted ',', got %q", b)
+		}
+	}
+	if sum.Cmp(New(75, 2)) != 0 {
+		t.Fatalf("wanted 0.75, got %s", sum)
+	}
+}
+
+func TestDecoder_Errors(t *testing.T) {
+	for i, test := range [...]struct {
+		in     string
+		offset int64
+	}{
+		0: {"-", 1},
+		1: {"+x", 1},
+		2: {".", 1},
+		3: {"  1e", 4},
+		4: {"1e+x", 3},
+		5: {"12.5E-", 6},
+		6: {"Infin", 5},
+		7: {"  nope", 3},
+		8: {"infinityy", 8},
+	} {
+		z := new(Big)
+		z.Context.OperatingMode = GDA
+		err := NewDecoder(strings.NewReader(test.in)).Decode(z)
+		serr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Fatalf("#%d: %q: wanted *SyntaxError, got %v", i, test.in, err)
+		}
+		if serr.Offset != test.offset {
+			t.Fatalf("#%d: %q: wanted offset %d, got %d (%v)", i, test.in, test.offset, serr.Offset, err)
+		}
+	}
+}
+
+func TestDecoder_Range(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		inf  int // sign of the wanted Inf, or 0 for zero
+		cond Condition
+	}{
+		0: {"1e9999999999", +1, Overflow},
+		1: {"-1e9999999999", -1, Overflow},
+		2: {"1e-9999999999", 0, Underflow},
+	} {
+		z := new(Big)
+		z.Context.OperatingMode = GDA
+		if err := NewDecoder(strings.NewReader(test.in)).Decode(z); err == nil {
+			t.Fatalf("#%d: %q: wanted an error", i, test.in)
+		}
+		if test.inf != 0 && !z.IsInf(test.inf) || test.inf == 0 && z.Sign() != 0 {
+			t.Fatalf("#%d: %q: got %s", i, test.in, z)
+		}
+		var x Big
+		x.Context.OperatingMode = GDA
+		x.SetString(test.in)
+		if z.Cmp(&x) != 0 || z.Context.Conditions != x.Context.Conditions {
+			t.Fatalf("#%d: %q: got %s (%s), but SetString gives %s (%s)",
+				i, test.in, z, z.Context.Conditions, &x, x.Context.Conditions)
+		}
+		if z.Context.Conditions&test.cond == 0 {
+			t.Fatalf("#%d: %q: wanted %s, got %s", i, test.in, test.cond, z.Context.Conditions)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package decimal
+
+// Densely packed decimal (DPD) encodes three decimal digits in ten bits. The
+// following is from Mike Cowlishaw's "A Summary of Densely Packed Decimal
+// encoding" (http://speleotrove.com/decimal/DPDecimal.html).
+//
+// The three BCD digits abcd efgh ijkm are encoded as pqr stu v wxy:
+//
+//     aei | pqr stu v wxy
+//     ----+--------------
+//     000 | bcd fgh 0 jkm
+//     001 | bcd fgh 1 00m
+//     010 | bcd jkh 1 01m
+//     100 | jkd fgh 1 10m
+//     110 | jkd 00h 1 11m
+//     101 | fgd 01h 1 11m
+//     011 | bcd 10h 1 11m
+//     111 | 00d 11h 1 11m
+//
+// a, e, and i are set if the corresponding digit is >= 8, in which case the
+// digit is 100d, 100h, or 100m, respectively.
+
+var (
+	// binToDPD maps an integer in [0, 999] to its declet.
+	binToDPD [1000]uint16
+
+	// dpdToBin maps a declet, including the 24 non-canonical declets, to an
+	// integer in [0, 999].
+	dpdToBin [1024]uint16
+)
+
+func init() {
+	for i := range binToDPD {
+		binToDPD[i] = encodeDeclet(uint16(i))
+	}
+	for i := range dpdToBin {
+		dpdToBin[i] = decodeDeclet(uint16(i))
+	}
+}
+
+// encodeDeclet returns the declet for x, which must be in [0, 999].
+func encodeDeclet(x uint16) uint16 {
+	d2, d1, d0 := x/100, x/10%10, x%10
+	bcd, fgh, jkm := d2&7, d1&7, d0&7
+	d, h, m := d2&1, d1&1, d0&1
+	jk, fg := d0&6, d1&6
+
+	switch aei := (d2>>3)<<2 | (d1>>3)<<1 | d0>>3; aei {
+	case 0: // 000
+		return bcd<<7 | fgh<<4 | jkm
+	case 1: // 001
+		return bcd<<7 | fgh<<4 | 0x8 | m
+	case 2: // 010
+		return bcd<<7 | (jk|h)<<4 | 0xA | m
+	case 4: // 100
+		return (jk|d)<<7 | fgh<<4 | 0xC | m
+	case 6: // 110
+		return (jk|d)<<7 | h<<4 | 0xE | m
+	case 5: // 101
+		return (fg|d)<<7 | (0x2|h)<<4 | 0xE | m
+	case 3: // 011
+		return bcd<<7 | (0x4|h)<<4 | 0xE | m
+	default: // 111
+		return d<<7 | (0x6|h)<<4 | 0xE | m
+	}
+}
+
+// decodeDeclet returns the integer in [0, 999] encoded by the declet x.
+func decodeDeclet(x uint16) uint16 {
+	var (
+		pqr = x >> 7 & 7
+		stu = x >> 4 & 7
+		wxy = x & 7
+		pq  = x >> 7 & 6
+		st  = x >> 4 & 6
+		r   = x >> 7 & 1
+		u   = x >> 4 & 1
+		y   = x & 1
+	)
+	var d2, d1, d0 uint16
+	switch {
+	case x&0x8 == 0: // v == 0
+		d2, d1, d0 = pqr, stu, wxy
+	case wxy&6 == 0: // wx == 00
+		d2, d1, d0 = pqr, stu, 8|y
+	case wxy&6 == 2: // wx == 01
+		d2, d1, d0 = pqr, 8|u, st|y
+	case wxy&6 == 4: // wx == 10
+		d2, d1, d0 = 8|r, stu, pq|y
+	case st == 0: // wx == 11, st == 00
+		d2, d1, d0 = 8|r, 8|u, pq|y
+	case st == 2: // wx == 11, st == 01
+		d2, d1, d0 = 8|r, pq|u, 8|y
+	case st == 4: // wx == 11, st == 10
+		d2, d1, d0 = pqr, 8|u, 8|y
+	default: // wx == 11, st == 11
+		d2, d1, d0 = 8|r, 8|u, 8|y
+	}
+	return d2*100 + d1*10 + d0
+}
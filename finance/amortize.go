@@ -0,0 +1,73 @@
+package finance
+
+import (
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+)
+
+// Installment is a single payment in an amortization schedule.
+type Installment struct {
+	// Period is the period in which the payment is made, starting at 1.
+	Period int
+
+	// Payment is the total amount paid. It is the sum of Interest and
+	// Principal.
+	Payment *decimal.Big
+
+	// Interest is the portion of Payment that pays the interest accrued
+	// during the period.
+	Interest *decimal.Big
+
+	// Principal is the portion of Payment that repays the loan.
+	Principal *decimal.Big
+
+	// Balance is the amount still owed after the payment.
+	Balance *decimal.Big
+}
+
+// Amortize returns the schedule for repaying a loan of pv in nper equal
+// payments made at the end of each period at the periodic interest rate,
+// rate.
+//
+// Every amount is quantized to scale, the number of digits in the currency's
+// minor unit (e.g., 2 for cents), using ctx's RoundingMode. Because the
+// payments are rounded, the final payment is adjusted so that the loan is
+// exactly repaid. All amounts are positive and have ctx as their Context.
+func Amortize(ctx decimal.Context, pv, rate *decimal.Big, nper int, scale int32) []Installment {
+	if nper <= 0 {
+		return nil
+	}
+
+	newBig := func() *decimal.Big {
+		x := new(decimal.Big)
+		x.Context = ctx
+		return x
+	}
+
+	pmt := newBig()
+	PMT(pmt, rate, decimal.New(int64(nper), 0), pv, nil, End)
+	pmt.Neg(pmt).Quantize(scale)
+
+	var (
+		wp      = ctx.Precision() + calc.GuardDigits
+		balance = calc.Work(wp).Set(pv)
+		sched   = make([]Installment, nper)
+	)
+	for i := range sched {
+		inst := &sched[i]
+		inst.Period = i + 1
+		inst.Interest = newBig().Mul(balance, rate)
+		inst.Interest.Quantize(scale)
+		if i == nper-1 {
+			inst.Principal = newBig().Set(balance)
+			inst.Payment = newBig().Add(inst.Principal, inst.Interest)
+		} else {
+			inst.Principal = newBig().Sub(pmt, inst.Interest)
+			inst.Payment = newBig().Set(pmt)
+		}
+		balance.Sub(balance, inst.Principal)
+		inst.Balance = newBig().Set(balance)
+		inst.Balance.Quantize(scale)
+	}
+	return sched
+}
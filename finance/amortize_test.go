@@ -0,0 +1,63 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestAmortize(t *testing.T) {
+	var ctx decimal.Context
+	sched := Amortize(ctx, decimal.New(1000, 0), decimal.New(1, 2), 12, 2)
+
+	// Period, payment, interest, principal, balance.
+	want := [...][5]string{
+		{"1", "88.85", "10.00", "78.85", "921.15"},
+		{"2", "88.85", "9.21", "79.64", "841.51"},
+		{"3", "88.85", "8.42", "80.43", "761.08"},
+		{"4", "88.85", "7.61", "81.24", "679.84"},
+		{"5", "88.85", "6.80", "82.05", "597.79"},
+		{"6", "88.85", "5.98", "82.87", "514.92"},
+		{"7", "88.85", "5.15", "83.70", "431.22"},
+		{"8", "88.85", "4.31", "84.54", "346.68"},
+		{"9", "88.85", "3.47", "85.38", "261.30"},
+		{"10", "88.85", "2.61", "86.24", "175.06"},
+		{"11", "88.85", "1.75", "87.10", "87.96"},
+		{"12", "88.84", "0.88", "87.96", "0.00"},
+	}
+	if len(sched) != len(want) {
+		t.Fatalf("wanted %d installments, got %d", len(want), len(sched))
+	}
+
+	total := new(decimal.Big)
+	for i, inst := range sched {
+		got := [...]*decimal.Big{
+			decimal.New(int64(inst.Period), 0),
+			inst.Payment, inst.Interest, inst.Principal, inst.Balance,
+		}
+		for j, g := range got {
+			if w := newbig(t, want[i][j]); g.Cmp(w) != 0 {
+				t.Fatalf("#%d.%d: wanted %s, got %s", i, j, w, g)
+			}
+			if j > 0 && g.Scale() != 2 {
+				t.Fatalf("#%d.%d: wanted scale 2, got %d", i, j, g.Scale())
+			}
+		}
+		total.Add(total, inst.Principal)
+	}
+	if total.Cmp(decimal.New(1000, 0)) != 0 {
+		t.Fatalf("principal: wanted 1000, got %s", total)
+	}
+
+	// Rounding the payment down leaves more for the final installment.
+	ctx.RoundingMode = decimal.ToZero
+	sched = Amortize(ctx, decimal.New(1000, 0), decimal.New(1, 2), 12, 2)
+	if pmt := sched[0].Payment; pmt.Cmp(newbig(t, "88.84")) != 0 {
+		t.Fatalf("wanted payment 88.84, got %s", pmt)
+	}
+	last := sched[len(sched)-1]
+	if last.Payment.Cmp(newbig(t, "88.84")) <= 0 || last.Balance.Sign() != 0 {
+		t.Fatalf("wanted final payment > 88.84 and zero balance, got %s and %s",
+			last.Payment, last.Balance)
+	}
+}
@@ -0,0 +1,103 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+	"github.com/ericlagergren/decimal/math"
+)
+
+// NPV sets z to the net present value of the periodic cash flows, values, at
+// the periodic discount rate, rate, and returns z. The first cash flow occurs
+// at the start of the first period and is not discounted.
+//
+// Spreadsheets usually discount the first cash flow by one period. To obtain
+// the same result, prepend a zero to values.
+func NPV(z, rate *decimal.Big, values []*decimal.Big) *decimal.Big {
+	wp := z.Context.Precision() + calc.GuardDigits
+	return z.Set(npv(calc.Work(wp), rate, values))
+}
+
+// npv sets z to the net present value of values at rate using z's precision.
+func npv(z, rate *decimal.Big, values []*decimal.Big) *decimal.Big {
+	// Horner's method:
+	//
+	//     v0 + v1/d + v2/d² + ... = v0 + (v1 + (v2 + ...)/d)/d
+	//
+	prec := z.Context.Precision()
+	d := calc.Work(prec).Add(one, rate)
+	r := calc.Work(prec)
+	for i := len(values) - 1; i >= 0; i-- {
+		if i < len(values)-1 {
+			r.Quo(r, d)
+		}
+		r.Add(r, values[i])
+	}
+	return z.Set(r)
+}
+
+// XNPV sets z to the net present value of the cash flows, values, occurring
+// on the given dates at the annual discount rate, rate, and returns z. Each
+// cash flow is discounted by the number of days since the first date divided
+// by 365. XNPV will panic if len(values) != len(dates).
+func XNPV(z, rate *decimal.Big, values []*decimal.Big, dates []time.Time) *decimal.Big {
+	wp := z.Context.Precision() + calc.GuardDigits
+	return z.Set(xnpv(calc.Work(wp), rate, values, dates))
+}
+
+// xnpv sets z to the net present value of values at dates and rate using z's
+// precision.
+func xnpv(z, rate *decimal.Big, values []*decimal.Big, dates []time.Time) *decimal.Big {
+	if len(values) != len(dates) {
+		panic("finance: len(values) != len(dates)")
+	}
+	var (
+		prec = z.Context.Precision()
+		d    = calc.Work(prec).Add(one, rate)
+		t    = calc.Work(prec)
+		e    = calc.Work(prec)
+		r    = calc.Work(prec)
+	)
+	for i, v := range values {
+		// v / d ** (days / 365)
+		e.Quo(decimal.New(days(dates[0], dates[i]), 0), daysInYear)
+		math.Pow(t, d, e)
+		r.Add(r, t.Quo(v, t))
+	}
+	return z.Set(r)
+}
+
+// days returns the number of calendar days from a to b.
+func days(a, b time.Time) int64 {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(b.Sub(a) / (24 * time.Hour))
+}
+
+// IRR sets z to the internal rate of return of the periodic cash flows,
+// values, and returns z. That is, it finds the rate at which the net present
+// value of values is zero.
+//
+// IRR returns the rate closest to zero that satisfies the equation. If no
+// rate does, IRR returns ErrNoSolution.
+func IRR(z *decimal.Big, values []*decimal.Big) (*decimal.Big, error) {
+	return root(z, func(y, r *decimal.Big) *decimal.Big {
+		return npv(y, r, values)
+	})
+}
+
+// XIRR sets z to the annual internal rate of return of the cash flows,
+// values, occurring on the given dates and returns z. That is, it finds the
+// rate at which XNPV is zero. XIRR will panic if len(values) != len(dates).
+//
+// XIRR returns the rate closest to zero that satisfies the equation. If no
+// rate does, XIRR returns ErrNoSolution.
+func XIRR(z *decimal.Big, values []*decimal.Big, dates []time.Time) (*decimal.Big, error) {
+	if len(values) != len(dates) {
+		panic("finance: len(values) != len(dates)")
+	}
+	return root(z, func(y, r *decimal.Big) *decimal.Big {
+		return xnpv(y, r, values, dates)
+	})
+}
@@ -0,0 +1,81 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func bigs(t *testing.T, s ...string) []*decimal.Big {
+	x := make([]*decimal.Big, len(s))
+	for i := range s {
+		x[i] = newbig(t, s[i])
+	}
+	return x
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+var (
+	xvalues = []string{"-10000", "2750", "4250", "3250", "2750"}
+	xdates  = []time.Time{
+		date(2008, 1, 1),
+		date(2008, 3, 1),
+		date(2008, 10, 30),
+		date(2009, 2, 15),
+		date(2009, 4, 1),
+	}
+)
+
+func TestNPV(t *testing.T) {
+	z := NPV(new(decimal.Big), newbig(t, "0.281"), bigs(t, "-100", "39", "59", "55", "20"))
+	if want := newbig(t, "-0.008478591638426131"); z.Cmp(want) != 0 {
+		t.Fatalf("wanted %s, got %s", want, z)
+	}
+}
+
+func TestXNPV(t *testing.T) {
+	z := XNPV(new(decimal.Big), newbig(t, "0.09"), bigs(t, xvalues...), xdates)
+	if want := newbig(t, "2086.647602031537"); z.Cmp(want) != 0 {
+		t.Fatalf("wanted %s, got %s", want, z)
+	}
+}
+
+func TestIRR(t *testing.T) {
+	for i, test := range [...]struct {
+		values []string
+		want   string
+	}{
+		0: {[]string{"-100", "39", "59", "55", "20"}, "0.2809484211599611"},
+		1: {[]string{"-100", "60", "60"}, "0.1306623862918075"},
+		2: {[]string{"-100", "50", "50"}, "0"},
+	} {
+		z := new(decimal.Big)
+		if _, err := IRR(z, bigs(t, test.values...)); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want := newbig(t, test.want); z.Cmp(want) != 0 {
+			t.Fatalf(`#%d:
+wanted: %s
+got   : %s
+`, i, want, z)
+		}
+	}
+
+	if _, err := IRR(new(decimal.Big), bigs(t, "100", "100")); err != ErrNoSolution {
+		t.Fatalf("wanted %v, got %v", ErrNoSolution, err)
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	z := new(decimal.Big)
+	if _, err := XIRR(z, bigs(t, xvalues...), xdates); err != nil {
+		t.Fatal(err)
+	}
+	if want := newbig(t, "0.3733625335188315"); z.Cmp(want) != 0 {
+		t.Fatalf("wanted %s, got %s", want, z)
+	}
+}
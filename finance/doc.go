@@ -0,0 +1,15 @@
+// Package finance implements common financial functions, like the time value
+// of money and internal rate of return, using decimals.
+//
+// The functions follow the sign convention used by spreadsheets: cash paid
+// out is negative and cash received is positive. For example, borrowing 1,000
+// and repaying it in 12 monthly installments at 1% interest per month has a
+// positive present value and negative payments:
+//
+//     PMT(z, decimal.New(1, 2), decimal.New(12, 0), decimal.New(1000, 0), nil, End)
+//     // z == -88.84878867834171
+//
+// Calculations are performed with a few extra digits of precision and then
+// rounded using z's Context. Use Amortize, which quantizes each payment to a
+// currency's minor unit, to compute the actual amounts due.
+package finance
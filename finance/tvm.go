@@ -0,0 +1,136 @@
+package finance
+
+import (
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+	"github.com/ericlagergren/decimal/math"
+)
+
+// When is when payments are due within each period.
+type When uint8
+
+const (
+	// End means payments are due at the end of each period, as with an
+	// ordinary annuity or a typical loan.
+	End When = iota
+
+	// Begin means payments are due at the beginning of each period, as with
+	// an annuity due or rent.
+	Begin
+)
+
+// factors returns (1 + rate)**nper and the annuity factor
+//
+//     (1 + rate*when) * ((1 + rate)**nper - 1) / rate
+//
+// which is nper if rate == 0.
+func factors(rate, nper *decimal.Big, when When, prec int32) (g, a *decimal.Big) {
+	g = calc.Work(prec)
+	a = calc.Work(prec)
+	if rate.Sign() == 0 {
+		return g.SetMantScale(1, 0), a.Set(nper)
+	}
+	math.Pow(g, calc.Work(prec).Add(one, rate), nper)
+
+	a.Sub(g, one)
+	a.Quo(a, rate)
+	if when == Begin {
+		a.Mul(a, calc.Work(prec).Add(one, rate))
+	}
+	return g, a
+}
+
+// FV sets z to the future value of an investment with periodic constant
+// payments, pmt, and present value, pv, after nper periods at the periodic
+// interest rate, rate, and returns z. A nil pv is treated as zero.
+func FV(z, rate, nper, pmt, pv *decimal.Big, when When) *decimal.Big {
+	wp := z.Context.Precision() + calc.GuardDigits
+	g, a := factors(rate, nper, when, wp)
+
+	// fv = -(pv * g + pmt * a)
+	r := calc.Work(wp).Mul(orZero(pv), g)
+	r.Add(r, a.Mul(pmt, a))
+	return z.Set(r.Neg(r))
+}
+
+// PV sets z to the present value of an investment with periodic constant
+// payments, pmt, and future value, fv, after nper periods at the periodic
+// interest rate, rate, and returns z. A nil fv is treated as zero.
+func PV(z, rate, nper, pmt, fv *decimal.Big, when When) *decimal.Big {
+	wp := z.Context.Precision() + calc.GuardDigits
+	g, a := factors(rate, nper, when, wp)
+
+	// pv = -(fv + pmt * a) / g
+	r := calc.Work(wp).Mul(pmt, a)
+	r.Add(r, orZero(fv))
+	r.Quo(r, g)
+	return z.Set(r.Neg(r))
+}
+
+// PMT sets z to the periodic constant payment of a loan or investment with
+// present value, pv, and future value, fv, after nper periods at the periodic
+// interest rate, rate, and returns z. A nil fv is treated as zero.
+//
+// The result is not quantized; see Amortize.
+func PMT(z, rate, nper, pv, fv *decimal.Big, when When) *decimal.Big {
+	wp := z.Context.Precision() + calc.GuardDigits
+	g, a := factors(rate, nper, when, wp)
+
+	// pmt = -(fv + pv * g) / a
+	r := calc.Work(wp).Mul(pv, g)
+	r.Add(r, orZero(fv))
+	r.Quo(r, a)
+	return z.Set(r.Neg(r))
+}
+
+// NPer sets z to the number of periods required for a loan or investment with
+// periodic constant payments, pmt, and present value, pv, to reach the future
+// value, fv, at the periodic interest rate, rate, and returns z. A nil fv is
+// treated as zero. The result is usually not an integer.
+//
+// If no number of periods satisfies the equation (for example, if the
+// payments do not cover the interest) z will be set to a NaN.
+func NPer(z, rate, pmt, pv, fv *decimal.Big, when When) *decimal.Big {
+	wp := z.Context.Precision() + calc.GuardDigits
+	fv = orZero(fv)
+
+	if rate.Sign() == 0 {
+		// nper = -(fv + pv) / pmt
+		r := calc.Work(wp).Add(fv, pv)
+		r.Quo(r, pmt)
+		return z.Set(r.Neg(r))
+	}
+
+	// c = pmt * (1 + rate*when) / rate
+	// nper = ln((c - fv) / (c + pv)) / ln(1 + rate)
+	c := calc.Work(wp).Quo(pmt, rate)
+	if when == Begin {
+		c.Mul(c, calc.Work(wp).Add(one, rate))
+	}
+	r := calc.Work(wp).Sub(c, fv)
+	r.Quo(r, calc.Work(wp).Add(c, pv))
+	if r.Sign() <= 0 {
+		return z.SetNaN(false)
+	}
+	math.Log(r, r)
+	return z.Set(r.Quo(r, math.Log(calc.Work(wp), calc.Work(wp).Add(one, rate))))
+}
+
+// Rate sets z to the periodic interest rate of a loan or investment with
+// periodic constant payments, pmt, present value, pv, and future value, fv,
+// after nper periods and returns z. A nil fv is treated as zero.
+//
+// Rate returns the rate closest to zero that satisfies the equation. If no
+// rate does, Rate returns ErrNoSolution.
+func Rate(z, nper, pmt, pv, fv *decimal.Big, when When) (*decimal.Big, error) {
+	fv = orZero(fv)
+	f := func(y, r *decimal.Big) *decimal.Big {
+		// pv * g + pmt * a + fv = 0
+		prec := y.Context.Precision()
+		g, a := factors(r, nper, when, prec)
+		t := calc.Work(prec).Mul(pv, g)
+		t.Add(t, a.Mul(pmt, a))
+		return y.Add(t, fv)
+	}
+	return root(z, f)
+}
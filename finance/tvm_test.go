@@ -0,0 +1,82 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func newbig(t *testing.T, s string) *decimal.Big {
+	x, ok := new(decimal.Big).SetString(s)
+	if !ok {
+		t.Fatalf("bad input: %q", s)
+	}
+	return x
+}
+
+func TestTVM(t *testing.T) {
+	type fn func(z, a, b, c, d *decimal.Big, w When) *decimal.Big
+	for i, test := range [...]struct {
+		name       string
+		f          fn
+		a, b, c, d string
+		w          When
+		want       string
+	}{
+		0:  {"PMT", PMT, "0.01", "12", "1000", "0", End, "-88.84878867834171"},
+		1:  {"PMT", PMT, "0.01", "12", "1000", "0", Begin, "-87.96909770132842"},
+		2:  {"PMT", PMT, "0", "12", "1200", "0", End, "-100"},
+		3:  {"FV", FV, "0.004166666666666666666666666666666666667", "120", "-100", "-100", End, "15692.92889433582"},
+		4:  {"FV", FV, "0.004166666666666666666666666666666666667", "120", "-100", "-100", Begin, "15757.62984410485"},
+		5:  {"PV", PV, "0.004166666666666666666666666666666666667", "120", "-100", "15692.93", End, "-100.0006713162131"},
+		6:  {"PV", PV, "0", "10", "-100", "0", End, "1000"},
+		7:  {"NPer", NPer, "0.005833333333333333333333333333333333333", "-150", "8000", "0", End, "64.07334877066212"},
+		8:  {"NPer", NPer, "0.005833333333333333333333333333333333333", "-150", "8000", "0", Begin, "63.62363537435229"},
+		9:  {"NPer", NPer, "0", "-100", "1000", "0", End, "10"},
+		10: {"NPer", NPer, "0.1", "-10", "1000", "0", End, "NaN"},
+	} {
+		z := new(decimal.Big)
+		test.f(z, newbig(t, test.a), newbig(t, test.b), newbig(t, test.c), newbig(t, test.d), test.w)
+		if test.want == "NaN" {
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: %s: wanted NaN, got %s", i, test.name, z)
+			}
+			continue
+		}
+		if want := newbig(t, test.want); z.Cmp(want) != 0 {
+			t.Fatalf(`#%d: %s:
+wanted: %s
+got   : %s
+`, i, test.name, want, z)
+		}
+	}
+}
+
+func TestRate(t *testing.T) {
+	for i, test := range [...]struct {
+		nper, pmt, pv, fv string
+		want              string
+	}{
+		0: {"10", "0", "-3500", "10000", "0.1106908537107528"},
+		1: {"12", "-88.85", "1000", "0", "0.01000215778464997"},
+		2: {"10", "-100", "1000", "0", "0"},
+	} {
+		z := new(decimal.Big)
+		_, err := Rate(z, newbig(t, test.nper), newbig(t, test.pmt), newbig(t, test.pv), newbig(t, test.fv), End)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want := newbig(t, test.want); z.Cmp(want) != 0 {
+			t.Fatalf(`#%d:
+wanted: %s
+got   : %s
+`, i, want, z)
+		}
+	}
+
+	// Payments and present value with the same sign can never balance.
+	_, err := Rate(new(decimal.Big), decimal.New(10, 0), decimal.New(100, 0), decimal.New(1000, 0), nil, End)
+	if err != ErrNoSolution {
+		t.Fatalf("wanted %v, got %v", ErrNoSolution, err)
+	}
+}
@@ -0,0 +1,107 @@
+package finance
+
+import (
+	"errors"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+	"github.com/ericlagergren/decimal/math/solve"
+)
+
+// ErrNoSolution is returned when Rate, IRR, or XIRR cannot find a rate that
+// satisfies the equation.
+var ErrNoSolution = errors.New("finance: no solution found")
+
+// maxIter is the maximum number of iterations used by the root finders.
+const maxIter = 1000
+
+var (
+	zero       = decimal.New(0, 0)
+	one        = decimal.New(1, 0)
+	two        = decimal.New(2, 0)
+	ptOne      = decimal.New(1, 1)
+	negOne     = decimal.New(-1, 0)
+	daysInYear = decimal.New(365, 0)
+)
+
+// finite returns true if x is neither an infinity nor a NaN value.
+func finite(x *decimal.Big) bool {
+	return !x.IsInf(0) && !x.IsNaN(0)
+}
+
+// orZero returns x, or zero if x is nil.
+func orZero(x *decimal.Big) *decimal.Big {
+	if x == nil {
+		return zero
+	}
+	return x
+}
+
+// root sets z to the root of f closest to zero, searching the interval
+// (-1, +∞), and returns z.
+func root(z *decimal.Big, f solve.Func) (*decimal.Big, error) {
+	prec := z.Context.Precision()
+	wp := prec + calc.GuardDigits
+	a, b, err := bracket(f, wp)
+	if err != nil {
+		return z, err
+	}
+	if _, err := solve.Brent(z, f, a, b, prec, maxIter); err != nil {
+		if err == solve.ErrNotBracketed || err == solve.ErrMaxIterations {
+			err = ErrNoSolution
+		}
+		return z, err
+	}
+	return z, nil
+}
+
+// maxBracket is the number of times bracket expands its interval in each
+// direction. 0.1 * 2**maxBracket is larger than any sensible rate.
+const maxBracket = 24
+
+// bracket searches outward from zero for an interval containing a root of f.
+// It expands the upper bound by doubling steps and moves the lower bound
+// halfway towards -1 each time.
+func bracket(f solve.Func, prec int32) (a, b *decimal.Big, err error) {
+	var (
+		lo   = calc.Work(prec)
+		hi   = calc.Work(prec)
+		next = calc.Work(prec)
+		step = calc.Work(prec).Set(ptOne)
+		flo  = calc.Work(prec)
+		fhi  = calc.Work(prec)
+		fn   = calc.Work(prec)
+	)
+	if f(flo, lo); !finite(flo) {
+		return nil, nil, ErrNoSolution
+	}
+	if flo.Sign() == 0 {
+		return lo, hi, nil
+	}
+	fhi.Set(flo)
+
+	for i := 0; i < maxBracket; i++ {
+		// hi := hi + step
+		next.Add(hi, step)
+		step.Mul(step, two)
+		if f(fn, next); finite(fn) {
+			if fn.Sign() != fhi.Sign() {
+				return hi, next, nil
+			}
+			hi, next = next, hi
+			fhi.Set(fn)
+		}
+
+		// lo := (lo - 1) / 2
+		next.Add(lo, negOne)
+		next.Quo(next, two)
+		if f(fn, next); finite(fn) {
+			if fn.Sign() != flo.Sign() {
+				return next, lo, nil
+			}
+			lo, next = next, lo
+			flo.Set(fn)
+		}
+	}
+	return nil, nil, ErrNoSolution
+}
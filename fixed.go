@@ -0,0 +1,380 @@
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	"github.com/ericlagergren/decimal/internal/arith/checked"
+	"github.com/ericlagergren/decimal/internal/arith/pow"
+	"github.com/ericlagergren/decimal/internal/c"
+)
+
+// Fixed is an immutable, fixed-point decimal number. Like Big, a Fixed is an
+// unscaled value and a scale, but the unscaled value is an int64 and there is
+// no Context, so a Fixed is small enough to be stored by value in slices and
+// maps without allocating.
+//
+// Fixed's methods have value receivers and return new Fixed values. Addition,
+// subtraction, and multiplication are exact; if a result does not fit into an
+// int64 it's transparently stored as a *Big instead, so only values that
+// overflow an int64 allocate.
+//
+// The zero value for a Fixed corresponds with 0. Fixed values are always
+// finite. Because 1.0 and 1.00 have different scales, Fixed values should be
+// compared with Cmp, not ==.
+type Fixed struct {
+	compact int64
+	scale   int32
+
+	// big is only used if the value is too large to fit in compact. It's
+	// never modified once it's been assigned to a Fixed.
+	big *Big
+}
+
+// NewFixed returns a Fixed with the given value and scale. For example,
+// NewFixed(1234, 2) is 12.34.
+func NewFixed(value int64, scale int32) Fixed {
+	return Fixed{compact: value, scale: scale}
+}
+
+// Fixed returns x as a Fixed and true or, if x is not finite, the zero value
+// and false.
+func (x *Big) Fixed() (Fixed, bool) {
+	if x.form&(nan|inf) != 0 {
+		return Fixed{}, false
+	}
+	return fixed(new(Big).Copy(x)), true
+}
+
+// SetFixed sets z to x and returns z. Unlike Set, SetFixed does not round z
+// to its Context's precision.
+func (z *Big) SetFixed(x Fixed) *Big {
+	if x.big != nil {
+		return z.Copy(x.big)
+	}
+	z.compact = x.compact
+	z.scale = x.scale
+	z.form = finite
+	if x.compact == 0 {
+		z.form = zero
+	} else if x.compact == c.Inflated {
+		// compact's sentinel value is a valid Fixed.
+		z.unscaled.SetInt64(x.compact)
+	}
+	return z
+}
+
+// Big returns x as a new *Big.
+func (x Fixed) Big() *Big { return new(Big).SetFixed(x) }
+
+// fixed returns b, which must be finite, as a Fixed. b must not be modified
+// after calling fixed.
+func fixed(b *Big) Fixed {
+	switch {
+	case b.form&(nan|inf) != 0:
+		panic("decimal: Fixed overflow")
+	case b.form <= nzero:
+		return Fixed{scale: b.scale}
+	case b.isCompact():
+		return Fixed{compact: b.compact, scale: b.scale}
+	case b.unscaled.IsInt64():
+		return Fixed{compact: b.unscaled.Int64(), scale: b.scale}
+	default:
+		return Fixed{scale: b.scale, big: b}
+	}
+}
+
+// load returns x as a *Big, using tmp as storage if necessary. The result must
+// not be modified.
+func (x Fixed) load(tmp *Big) *Big {
+	if x.big != nil {
+		return x.big
+	}
+	return tmp.SetFixed(x)
+}
+
+// exact returns a new *Big whose operations are not rounded.
+func exact() *Big {
+	z := new(Big)
+	z.Context.SetPrecision(0)
+	return z
+}
+
+// unscaled sets z to x's unscaled value and returns z.
+func (x Fixed) unscaled(z *big.Int) *big.Int {
+	if x.big != nil {
+		return unscaledOf(z, x.big)
+	}
+	return z.SetInt64(x.compact)
+}
+
+// Scale returns x's scale.
+func (x Fixed) Scale() int32 { return x.scale }
+
+// Sign returns
+//
+//   -1 if x <  0
+//    0 if x == 0
+//   +1 if x >  0
+//
+func (x Fixed) Sign() int {
+	if x.big != nil {
+		return x.big.Sign()
+	}
+	switch {
+	case x.compact < 0:
+		return -1
+	case x.compact > 0:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Cmp compares x and y and returns
+//
+//   -1 if x <  y
+//    0 if x == y
+//   +1 if x >  y
+//
+func (x Fixed) Cmp(y Fixed) int {
+	if x.big == nil && y.big == nil {
+		xc, yc := x.compact, y.compact
+		d, ok := checked.Sub32(x.scale, y.scale)
+		if ok && d < 0 {
+			xc, ok = mulPow10(xc, -d)
+		} else if ok && d > 0 {
+			yc, ok = mulPow10(yc, d)
+		}
+		if ok {
+			switch {
+			case xc < yc:
+				return -1
+			case xc > yc:
+				return +1
+			default:
+				return 0
+			}
+		}
+	}
+	var a, b Big
+	return x.load(&a).Cmp(y.load(&b))
+}
+
+// Neg returns -x.
+func (x Fixed) Neg() Fixed {
+	if x.big == nil && x.compact != math.MinInt64 {
+		return Fixed{compact: -x.compact, scale: x.scale}
+	}
+	if x.big != nil {
+		return fixed(exact().Neg(x.big))
+	}
+	// -math.MinInt64 overflows an int64.
+	z := new(Big)
+	z.unscaled.Neg(big.NewInt(x.compact))
+	z.compact = c.Inflated
+	z.scale = x.scale
+	z.form = finite
+	return fixed(z)
+}
+
+// Abs returns |x|.
+func (x Fixed) Abs() Fixed {
+	if x.Sign() >= 0 {
+		return x
+	}
+	return x.Neg()
+}
+
+// Add returns x + y. The scale of the result is the larger of x's and y's
+// scales.
+func (x Fixed) Add(y Fixed) Fixed {
+	if x.big == nil && y.big == nil {
+		hi, lo := x, y
+		if hi.scale < lo.scale {
+			hi, lo = lo, hi
+		}
+		if d, ok := checked.Sub32(hi.scale, lo.scale); ok {
+			if lc, ok := mulPow10(lo.compact, d); ok {
+				if sum, ok := checked.Add(hi.compact, lc); ok {
+					return Fixed{compact: sum, scale: hi.scale}
+				}
+			}
+		}
+	}
+	var a, b Big
+	return fixed(exact().Add(x.load(&a), y.load(&b)))
+}
+
+// Sub returns x - y. The scale of the result is the larger of x's and y's
+// scales.
+func (x Fixed) Sub(y Fixed) Fixed { return x.Add(y.Neg()) }
+
+// Mul returns x * y. The scale of the result is the sum of x's and y's
+// scales.
+func (x Fixed) Mul(y Fixed) Fixed {
+	scale, ok := checked.Add32(x.scale, y.scale)
+	if !ok {
+		panic("decimal: Fixed scale overflow")
+	}
+	if x.big == nil && y.big == nil {
+		if prod, ok := checked.Mul(x.compact, y.compact); ok {
+			return Fixed{compact: prod, scale: scale}
+		}
+	}
+	var a, b Big
+	return fixed(exact().Mul(x.load(&a), y.load(&b)))
+}
+
+// Quo returns x / y with the given scale, rounded using mode. Quo will panic
+// if y == 0 or mode is invalid.
+func (x Fixed) Quo(y Fixed, scale int32, mode RoundingMode) Fixed {
+	if y.Sign() == 0 {
+		panic("decimal: division by zero")
+	}
+
+	// x / y = (x.unscaled * 10^k) / y.unscaled * 10^-scale
+	k := int64(scale) - int64(x.scale) + int64(y.scale)
+
+	if x.big == nil && y.big == nil && k >= math.MinInt32 && k <= math.MaxInt32 {
+		num, den := x.compact, y.compact
+		ok := true
+		if k >= 0 {
+			num, ok = mulPow10(num, int32(k))
+		} else {
+			den, ok = mulPow10(den, int32(-k))
+		}
+		// MinInt64 / -1 overflows.
+		if ok && (num != math.MinInt64 || den != -1) {
+			q, r := num/den, num%den
+			if r != 0 {
+				h := halfCmp(absUint64(r), absUint64(den))
+				if fixedInc(mode, h, (num < 0) == (den < 0), q&1 != 0) {
+					if (num < 0) == (den < 0) {
+						q++
+					} else {
+						q--
+					}
+				}
+			}
+			return Fixed{compact: q, scale: scale}
+		}
+	}
+
+	num := x.unscaled(new(big.Int))
+	den := y.unscaled(new(big.Int))
+	if k > 0 {
+		num.Mul(num, pow.BigTen(k))
+	} else if k < 0 {
+		den.Mul(den, pow.BigTen(-k))
+	}
+	q, r := num.QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		pos := r.Sign() == den.Sign()
+		h := arith.BigAbsCmp(new(big.Int).Lsh(r, 1), den)
+		if fixedInc(mode, h, pos, q.Bit(0) != 0) {
+			if pos {
+				q.Add(q, oneInt)
+			} else {
+				q.Sub(q, oneInt)
+			}
+		}
+	}
+	z := new(Big)
+	z.unscaled.Set(q)
+	z.compact = c.Inflated
+	z.scale = scale
+	z.form = finite
+	if q.Sign() == 0 {
+		z.form = zero
+	}
+	return fixed(z)
+}
+
+// Round returns x rounded to the given scale using mode. If scale is larger
+// than x's scale x is padded with zeros, which is always exact. Round will
+// panic if mode is invalid.
+func (x Fixed) Round(scale int32, mode RoundingMode) Fixed {
+	return x.Quo(Fixed{compact: 1}, scale, mode)
+}
+
+// mulPow10 is like checked.MulPow10 but does not treat c.Inflated specially.
+func mulPow10(x int64, n int32) (int64, bool) {
+	if x == c.Inflated && n > 0 {
+		return 0, false
+	}
+	return checked.MulPow10(x, n)
+}
+
+// fixedInc reports whether the magnitude of a quotient should be incremented.
+// See roundsUp.
+func fixedInc(mode RoundingMode, c int, pos, odd bool) bool {
+	inc, ok := roundsUp(mode, c, pos, odd)
+	if !ok {
+		panic("decimal: invalid rounding mode: " + mode.String())
+	}
+	return inc
+}
+
+// halfCmp compares the remainder r against half the divisor d, which must be
+// larger than r, and returns -1, 0, or +1.
+func halfCmp(r, d uint64) int {
+	// r < d, so d-r cannot underflow and 2r is never computed.
+	switch e := d - r; {
+	case r < e:
+		return -1
+	case r > e:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// absUint64 returns |x|. Unlike arith.Abs, it's correct for math.MinInt64.
+func absUint64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-x)
+	}
+	return uint64(x)
+}
+
+// String returns x in plain notation with exactly x.Scale() digits following
+// the radix, like "12.30". If x's scale is negative, x is written as an
+// integer.
+func (x Fixed) String() string {
+	if x.big != nil {
+		return string(x.appendTo(nil, x.unscaled(new(big.Int)).String()))
+	}
+	var buf [24]byte
+	return string(x.appendTo(nil, string(strconv.AppendInt(buf[:0], x.compact, 10))))
+}
+
+// appendTo appends x to dst, given the decimal string of x's unscaled value.
+func (x Fixed) appendTo(dst []byte, digits string) []byte {
+	if digits[0] == '-' {
+		dst = append(dst, '-')
+		digits = digits[1:]
+	}
+	if x.scale <= 0 {
+		dst = append(dst, digits...)
+		if digits != "0" {
+			for i := x.scale; i < 0; i++ {
+				dst = append(dst, '0')
+			}
+		}
+		return dst
+	}
+	n := int(x.scale)
+	if len(digits) <= n {
+		dst = append(dst, '0', '.')
+		for i := len(digits); i < n; i++ {
+			dst = append(dst, '0')
+		}
+		return append(dst, digits...)
+	}
+	dst = append(dst, digits[:len(digits)-n]...)
+	dst = append(dst, '.')
+	return append(dst, digits[len(digits)-n:]...)
+}
@@ -0,0 +1,91 @@
+package decimal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFixed(t *testing.T) {
+	max := NewFixed(math.MaxInt64, 0)
+	for i, test := range [...]struct {
+		got  Fixed
+		want string
+	}{
+		0:  {NewFixed(1234, 2), "12.34"},
+		1:  {NewFixed(-5, 3), "-0.005"},
+		2:  {NewFixed(12, -2), "1200"},
+		3:  {Fixed{}, "0"},
+		4:  {NewFixed(150, 2).Add(NewFixed(25, 1)), "4.00"},
+		5:  {NewFixed(150, 2).Sub(NewFixed(25, 1)), "-1.00"},
+		6:  {NewFixed(150, 2).Mul(NewFixed(-3, 1)), "-0.450"},
+		7:  {max.Add(NewFixed(1, 0)), "9223372036854775808"},
+		8:  {max.Mul(max), "85070591730234615847396907784232501249"},
+		9:  {max.Add(NewFixed(1, 0)).Sub(NewFixed(2, 0)), "9223372036854775806"},
+		10: {NewFixed(math.MinInt64, 0).Neg(), "9223372036854775808"},
+		11: {NewFixed(-7, 1).Abs(), "0.7"},
+		12: {NewFixed(1, 0).Quo(NewFixed(3, 0), 4, ToNearestEven), "0.3333"},
+		13: {NewFixed(2, 0).Quo(NewFixed(3, 0), 4, ToNearestEven), "0.6667"},
+		14: {NewFixed(-2, 0).Quo(NewFixed(3, 0), 4, ToZero), "-0.6666"},
+		15: {NewFixed(-2, 0).Quo(NewFixed(3, 0), 4, ToNegativeInf), "-0.6667"},
+		16: {NewFixed(1, 0).Quo(NewFixed(8, 0), 2, ToNearestEven), "0.12"},
+		17: {NewFixed(1, 0).Quo(NewFixed(8, 0), 2, ToNearestAway), "0.13"},
+		18: {max.Quo(NewFixed(7, 0), 20, ToNearestEven), "1317624576693539401.00000000000000000000"},
+		19: {max.Mul(max).Quo(max, 0, ToNearestEven), "9223372036854775807"},
+		20: {NewFixed(12345, 3).Round(1, ToNearestEven), "12.3"},
+		21: {NewFixed(12355, 3).Round(2, ToNearestEven), "12.36"},
+		22: {NewFixed(-12355, 3).Round(2, ToNearestEven), "-12.36"},
+		23: {NewFixed(125, 2).Round(5, ToNearestEven), "1.25000"},
+		24: {NewFixed(125, 2).Round(-1, ToPositiveInf), "10"},
+		25: {NewFixed(math.MinInt64, 0).Quo(NewFixed(-1, 0), 0, ToNearestEven), "9223372036854775808"},
+	} {
+		if s := test.got.String(); s != test.want {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.want, s)
+		}
+	}
+}
+
+func TestFixed_Cmp(t *testing.T) {
+	max := NewFixed(math.MaxInt64, 0)
+	for i, test := range [...]struct {
+		x, y Fixed
+		want int
+	}{
+		0: {NewFixed(1, 0), NewFixed(100, 2), 0},
+		1: {NewFixed(1, 0), NewFixed(101, 2), -1},
+		2: {NewFixed(-1, 0), NewFixed(-101, 2), +1},
+		3: {max, NewFixed(1, 1), +1},
+		4: {NewFixed(1, 1), max, -1},
+		5: {max.Add(max), max, +1},
+		6: {Fixed{}, NewFixed(0, 5), 0},
+	} {
+		if got := test.x.Cmp(test.y); got != test.want {
+			t.Fatalf("#%d: %s cmp %s: wanted %d, got %d", i, test.x, test.y, test.want, got)
+		}
+	}
+}
+
+func TestFixed_Big(t *testing.T) {
+	for i, s := range [...]string{"0", "12.34", "-0.005", "1E+5", "123456789012345678901234567890.5"} {
+		x := newbig(t, s)
+		f, ok := x.Fixed()
+		if !ok {
+			t.Fatalf("#%d: wanted true, got false", i)
+		}
+		if y := f.Big(); y.Cmp(x) != 0 || y.Scale() != x.Scale() {
+			t.Fatalf("#%d: wanted %s, got %s", i, x, y)
+		}
+	}
+	if _, ok := newbig(t, "Inf").Fixed(); ok {
+		t.Fatal("Inf: wanted false, got true")
+	}
+}
+
+func TestFixed_Allocs(t *testing.T) {
+	x, y := NewFixed(1234, 2), NewFixed(-5, 3)
+	n := testing.AllocsPerRun(100, func() {
+		x.Add(y).Mul(y).Quo(x, 4, ToNearestEven).Round(2, ToNearestEven).Cmp(y)
+	})
+	if n != 0 {
+		t.Fatalf("wanted 0 allocations, got %v", n)
+	}
+}
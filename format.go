@@ -1,8 +1,6 @@
 package decimal
 
 import (
-	"fmt"
-	"io"
 	"math/big"
 	"strconv"
 )
@@ -84,13 +82,13 @@ func roundString(b []byte, mode RoundingMode, pos bool, prec int) []byte {
 	return b[:prec]
 }
 
-// formatCompact formats the compact decimal, x, as an unsigned integer.
-func formatCompact(x int64) []byte {
+// formatCompact appends the compact decimal, x, to dst as an unsigned
+// integer.
+func formatCompact(dst []byte, x int64) []byte {
 	if x < 0 {
 		x = -x
 	}
-	var b [20]byte
-	return strconv.AppendUint(b[0:0], uint64(x), 10)
+	return strconv.AppendUint(dst, uint64(x), 10)
 }
 
 // formatUnscaled formats the unscaled (non-compact) decimal, unscaled, as an
@@ -117,35 +115,81 @@ const (
 	normal = iota // either sci or plain, depending on x
 	plain         // forced plain
 	sci           // forced sci
+	eng           // either eng or plain, depending on x
+	sciEng        // forced sci with an engineering exponent
 )
 
+// formatter appends formatted decimals to buf. It writes into a byte slice
+// instead of an io.Writer so that formatting doesn't have to allocate.
 type formatter struct {
-	w interface {
-		io.Writer
-		io.ByteWriter
-		WriteString(string) (int, error)
-	}
-	sign  byte  // leading '+' or ' ' flag
-	prec  int   // total precision
-	width int   // min width
-	n     int64 // cumulative number of bytes written to w
+	buf   []byte
+	sign  byte // leading '+' or ' ' flag
+	prec  int  // total precision
+	width int  // min width
 }
 
 func (f *formatter) WriteByte(c byte) error {
-	f.n++
-	return f.w.WriteByte(c)
+	f.buf = append(f.buf, c)
+	return nil
 }
 
 func (f *formatter) WriteString(s string) (int, error) {
-	m, err := f.w.WriteString(s)
-	f.n += int64(m)
-	return m, err
+	f.buf = append(f.buf, s...)
+	return len(s), nil
 }
 
 func (f *formatter) Write(p []byte) (n int, err error) {
-	n, err = f.w.Write(p)
-	f.n += int64(n)
-	return n, err
+	f.buf = append(f.buf, p...)
+	return len(p), nil
+}
+
+// writeZeros writes n '0's.
+func (f *formatter) writeZeros(n int) {
+	for ; n > 0; n-- {
+		f.buf = append(f.buf, '0')
+	}
+}
+
+// verb formats x according to the fmt verb c, which must be one of the verbs
+// accepted by Append. It reports whether c was accepted.
+func (f *formatter) verb(x *Big, c byte, hash bool) bool {
+	// noE is a placeholder for formats that do not use scientific notation
+	// and don't require 'e' or 'E'
+	const noE = 0
+	switch c {
+	case 's', 'd':
+		f.format(x, normal, 'e')
+	case 'e', 'E':
+		if hash {
+			f.format(x, sciEng, c)
+		} else {
+			f.format(x, sci, c)
+		}
+	case 'f':
+		// %f's precision means "number of digits after the radix", so round
+		// x to that scale like Quantize does instead of counting digits: the
+		// rounding can carry into a new digit (9.99 -> 10.0) or leave no
+		// digits at all (0.001 -> 0.0).
+		prec := f.prec
+		if prec != noPrec && x != nil && x.form == finite && prec < int(x.scale) {
+			var t Big
+			x = t.Copy(x).Quantize(int32(prec))
+		}
+		f.prec = noPrec
+		start := len(f.buf)
+		f.format(x, plain, noE)
+		// Like strconv.AppendFloat, pad the fraction with zeros out to prec
+		// digits since formatPlain trims them.
+		if prec > 0 && x != nil && x.form <= finite {
+			f.padFrac(start, prec)
+		}
+	case 'g':
+		// %g's precision means "number of significant digits"
+		f.format(x, plain, noE)
+	default:
+		return false
+	}
+	return true
 }
 
 var stringForms = [...]struct{ snan, qnan, pinf, ninf string }{
@@ -165,15 +209,12 @@ func (f *formatter) format(x *Big, format, e byte) {
 		case Go, GDA:
 			switch m {
 			case nzero:
-				f.WriteByte('-')
-				fallthrough
+				f.WriteString("-0")
 			case zero:
-				if f.width == noWidth {
-					f.WriteByte('0')
-				} else {
-					f.WriteString("0.")
-					io.CopyN(f, zeroReader{}, int64(f.width))
+				if f.sign != 0 {
+					f.WriteByte(f.sign)
 				}
+				f.WriteByte('0')
 			case snan:
 				f.WriteString(stringForms[o].snan)
 			case qnan:
@@ -196,11 +237,14 @@ func (f *formatter) format(x *Big, format, e byte) {
 		f.WriteByte(f.sign)
 	}
 
-	var b []byte
+	var (
+		b   []byte
+		tmp [20]byte
+	)
 	if x.isInflated() {
 		b = formatUnscaled(&x.unscaled)
 	} else {
-		b = formatCompact(x.compact)
+		b = formatCompact(tmp[:0], x.compact)
 	}
 
 	scale := int(x.scale)
@@ -215,6 +259,10 @@ func (f *formatter) format(x *Big, format, e byte) {
 	// is, exponent+(clength-1), where clength is the length of the coefficient
 	// in decimal digits.
 	adj := -scale + (len(b) - 1)
+	if format == sciEng {
+		f.formatEng(b, adj, e)
+		return
+	}
 	if format != sci {
 		if scale >= 0 && (format == plain || adj >= -6) {
 			// "If the exponent is less than or equal to zero and the adjusted
@@ -229,13 +277,50 @@ func (f *formatter) format(x *Big, format, e byte) {
 		// No decimal places, write b and fill with zeros.
 		if format == plain && scale < 0 {
 			f.Write(b)
-			io.CopyN(f, zeroReader{}, -int64(scale))
+			f.writeZeros(-scale)
+			return
+		}
+
+		if format == eng {
+			f.formatEng(b, adj, e)
 			return
 		}
 	}
 	f.formatSci(b, adj, e)
 }
 
+// formatEng returns the engineering version of b.
+func (f *formatter) formatEng(b []byte, adj int, e byte) {
+	// "...the exponent will be a multiple of three, and there may be up to
+	// three digits before any decimal point."
+	//
+	// - http://speleotrove.com/decimal/daconvs.html#reftoeng
+	n := adj % 3
+	if n < 0 {
+		n += 3
+	}
+	n++ // digits before the radix
+
+	if len(b) <= n {
+		f.Write(b)
+		f.writeZeros(n - len(b))
+	} else {
+		f.Write(b[:n])
+		if i := trimIndex(b[n:]); i > 0 {
+			f.WriteByte('.')
+			f.Write(b[n : n+i])
+		}
+	}
+
+	if adj -= n - 1; adj != 0 {
+		f.WriteByte(e)
+		if adj > 0 {
+			f.WriteByte('+')
+		}
+		f.buf = strconv.AppendInt(f.buf, int64(adj), 10)
+	}
+}
+
 // formatSci returns the scientific version of b.
 func (f *formatter) formatSci(b []byte, adj int, e byte) {
 	f.WriteByte(b[0])
@@ -256,12 +341,12 @@ func (f *formatter) formatSci(b []byte, adj int, e byte) {
 	if adj != 0 {
 		f.WriteByte(e)
 
-		// If negative, the following call to strconv.Append will add the minus
-		// sign for us.
+		// If negative, the following call to strconv.AppendInt will add the
+		// minus sign for us.
 		if adj > 0 {
 			f.WriteByte('+')
 		}
-		f.WriteString(strconv.Itoa(adj))
+		f.buf = strconv.AppendInt(f.buf, int64(adj), 10)
 	}
 }
 
@@ -289,7 +374,7 @@ func (f *formatter) formatPlain(b []byte, scale int) {
 	// log10(b) < scale, so before p "0s" and before b.
 	default:
 		f.WriteString(zeroRadix)
-		io.CopyN(f, zeroReader{}, -int64(radix))
+		f.writeZeros(-radix)
 
 		end := len(b)
 		if f.prec > noPrec && f.prec < end {
@@ -299,6 +384,23 @@ func (f *formatter) formatPlain(b []byte, scale int) {
 	}
 }
 
+// padFrac pads the number written to f.buf[start:] with zeros until it has
+// prec digits following the radix, adding the radix if need be.
+func (f *formatter) padFrac(start, prec int) {
+	n := -1 // no radix
+	for i, c := range f.buf[start:] {
+		if c == '.' {
+			n = len(f.buf) - (start + i + 1)
+			break
+		}
+	}
+	if n < 0 {
+		f.WriteByte('.')
+		n = 0
+	}
+	f.writeZeros(prec - n)
+}
+
 // TODO(eric): can we merge zeroReader and spaceReader into a "singleReader" or
 // something and still maintain the same performance?
 
@@ -350,16 +452,3 @@ func trimIndex(b []byte) int {
 	}
 	return -1
 }
-
-// stateWrapper is a wrapper around an io.Writer to add WriteByte and
-// WriteString methods.
-type stateWrapper struct{ fmt.State }
-
-func (w stateWrapper) WriteByte(c byte) error {
-	_, err := w.Write([]byte{c})
-	return err
-}
-
-func (w stateWrapper) WriteString(s string) (int, error) {
-	return io.WriteString(w.State, s)
-}
@@ -0,0 +1,194 @@
+// Package format formats decimals for people, using a locale's separators and
+// digit grouping.
+//
+// A Pattern combines a Locale with the number of fraction digits to show and
+// the affixes for positive and negative numbers. For example, an invoice
+// total in German and an accounting entry in Indian English:
+//
+//     p := format.Pattern{Locale: format.DeDE, MinFrac: 2, MaxFrac: 2, Suffix: " €"}
+//     p.Format(decimal.New(123456789, 2)) // "1.234.567,89 €"
+//
+//     p = format.Pattern{Locale: format.EnIN, MinFrac: 2, MaxFrac: 2,
+//         Prefix: "₹", NegPrefix: "(₹", NegSuffix: ")"}
+//     p.Format(decimal.New(-123456789, 2)) // "(₹12,34,567.89)"
+//
+package format
+
+import (
+	"math/big"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// Pattern describes how to format a number.
+type Pattern struct {
+	Locale
+
+	// MinInt is the minimum number of integer digits. Integers with fewer
+	// digits are padded with leading zeros. Values less than 1 are treated as
+	// 1.
+	MinInt int
+
+	// MinFrac and MaxFrac are the minimum and maximum number of fraction
+	// digits. Numbers with more than MaxFrac fraction digits are rounded using
+	// RoundingMode, trailing zeros are removed down to MinFrac digits, and
+	// numbers with fewer than MinFrac fraction digits are padded with zeros.
+	// If MaxFrac is less than MinFrac it's treated as MinFrac.
+	MinFrac, MaxFrac int
+
+	// RoundingMode is used to round numbers to MaxFrac fraction digits.
+	RoundingMode decimal.RoundingMode
+
+	// Prefix and Suffix surround non-negative numbers; for example, a
+	// currency symbol.
+	Prefix, Suffix string
+
+	// NegPrefix and NegSuffix surround negative numbers. If both are empty,
+	// negative numbers are written as Minus followed by Prefix, the number,
+	// and Suffix. For accounting-style parentheses, use "(" and ")".
+	NegPrefix, NegSuffix string
+}
+
+// Format returns x formatted according to p.
+func (p *Pattern) Format(x *decimal.Big) string {
+	return string(p.Append(make([]byte, 0, 24), x))
+}
+
+// Append appends x, formatted according to p, to dst and returns the extended
+// buffer.
+func (p *Pattern) Append(dst []byte, x *decimal.Big) []byte {
+	if x.IsNaN(0) {
+		return append(dst, p.NaN...)
+	}
+
+	maxFrac := p.MaxFrac
+	if maxFrac < p.MinFrac {
+		maxFrac = p.MinFrac
+	}
+
+	var digits []byte
+	if !x.IsInf(0) {
+		digits = p.digits(x, maxFrac)
+	}
+
+	// -0 is written as 0, including numbers that round to 0.
+	neg := x.Signbit() && (x.IsInf(0) || !allZeros(digits))
+	if neg {
+		if p.NegPrefix == "" && p.NegSuffix == "" {
+			dst = append(dst, p.Minus...)
+			dst = append(dst, p.Prefix...)
+		} else {
+			dst = append(dst, p.NegPrefix...)
+		}
+	} else {
+		dst = append(dst, p.Prefix...)
+	}
+
+	if x.IsInf(0) {
+		dst = append(dst, p.Infinity...)
+	} else {
+		dst = p.appendDigits(dst, digits, maxFrac)
+	}
+
+	if neg && (p.NegPrefix != "" || p.NegSuffix != "") {
+		return append(dst, p.NegSuffix...)
+	}
+	return append(dst, p.Suffix...)
+}
+
+// digits returns the digits of |x| rounded to frac digits following the
+// radix. The result has at least frac+1 digits.
+func (p *Pattern) digits(x *decimal.Big, frac int) []byte {
+	q := new(decimal.Big).Copy(x)
+	q.Context = decimal.Context{RoundingMode: p.RoundingMode}
+	q.Quantize(int32(frac))
+
+	var u big.Int
+	q.SetScale(0).Int(&u)
+	b, _ := u.Abs(&u).MarshalText()
+	if n := frac + 1 - len(b); n > 0 {
+		b = append(make([]byte, n, n+len(b)), b...)
+		for i := 0; i < n; i++ {
+			b[i] = '0'
+		}
+	}
+	return b
+}
+
+// appendDigits appends the grouped integer digits and the fraction digits of
+// b, which has frac fraction digits, to dst.
+func (p *Pattern) appendDigits(dst, b []byte, frac int) []byte {
+	intPart, fracPart := b[:len(b)-frac], b[len(b)-frac:]
+
+	// Trim the fraction down to MinFrac digits.
+	n := len(fracPart)
+	for n > p.MinFrac && fracPart[n-1] == '0' {
+		n--
+	}
+	fracPart = fracPart[:n]
+
+	// Remove leading zeros, then pad to MinInt digits.
+	for len(intPart) > 1 && intPart[0] == '0' {
+		intPart = intPart[1:]
+	}
+	pad := p.MinInt - len(intPart)
+	if pad < 0 {
+		pad = 0
+	}
+	n = pad + len(intPart)
+	for i := 0; i < n; i++ {
+		dst = p.appendGroup(dst, n-i, n)
+		if i < pad {
+			dst = append(dst, '0')
+		} else {
+			dst = append(dst, intPart[i-pad])
+		}
+	}
+
+	if len(fracPart) > 0 {
+		dst = append(dst, p.Decimal...)
+		dst = append(dst, fracPart...)
+	}
+	return dst
+}
+
+// appendGroup appends a group separator to dst if the digit that is left
+// digits from the radix begins a new group in an integer with n digits.
+func (p *Pattern) appendGroup(dst []byte, left, n int) []byte {
+	if left == n || len(p.Grouping) == 0 || p.Grouping[0] <= 0 {
+		return dst
+	}
+	min := p.MinGrouping
+	if min < 1 {
+		min = 1
+	}
+	if n < p.Grouping[0]+min {
+		return dst
+	}
+
+	// Walk the groups from the radix leftward until we've found whether a
+	// group ends at left.
+	pos := 0
+	for i := 0; pos < left; i++ {
+		size := p.Grouping[len(p.Grouping)-1]
+		if i < len(p.Grouping) {
+			size = p.Grouping[i]
+		}
+		if size <= 0 {
+			return dst
+		}
+		if pos += size; pos == left {
+			return append(dst, p.Group...)
+		}
+	}
+	return dst
+}
+
+func allZeros(b []byte) bool {
+	for _, c := range b {
+		if c != '0' {
+			return false
+		}
+	}
+	return true
+}
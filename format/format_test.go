@@ -0,0 +1,103 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func newbig(t *testing.T, s string) *decimal.Big {
+	x, ok := new(decimal.Big).SetString(s)
+	if !ok {
+		t.Fatalf("invalid decimal: %q", s)
+	}
+	return x
+}
+
+func TestPattern_Format(t *testing.T) {
+	var (
+		money = Pattern{Locale: EnUS, MinFrac: 2, MaxFrac: 2, Prefix: "$"}
+		acct  = Pattern{Locale: EnUS, MinFrac: 2, MaxFrac: 2, Prefix: "$", NegPrefix: "($", NegSuffix: ")"}
+		euro  = Pattern{Locale: DeDE, MinFrac: 2, MaxFrac: 2, Suffix: " €"}
+		inr   = Pattern{Locale: EnIN, MaxFrac: 2}
+		es    = Pattern{Locale: EsES, MaxFrac: 3}
+		fr    = Pattern{Locale: FrFR, MinFrac: 1, MaxFrac: 3}
+		sv    = Pattern{Locale: SvSE, MaxFrac: 2}
+		pad   = Pattern{Locale: EnUS, MinInt: 5, MinFrac: 1, MaxFrac: 1}
+		plain = Pattern{Locale: Locale{Decimal: ".", Minus: "-", Infinity: "Inf", NaN: "NaN"}, MaxFrac: 4}
+		down  = Pattern{Locale: EnUS, MaxFrac: 1, RoundingMode: decimal.ToZero}
+	)
+	for i, test := range [...]struct {
+		p    Pattern
+		x    string
+		want string
+	}{
+		0:  {money, "1234567.891", "$1,234,567.89"},
+		1:  {money, "-1234.5", "-$1,234.50"},
+		2:  {money, "0", "$0.00"},
+		3:  {money, "-0.001", "$0.00"},
+		4:  {money, "999.995", "$1,000.00"},
+		5:  {acct, "-1234.5", "($1,234.50)"},
+		6:  {acct, "1234.5", "$1,234.50"},
+		7:  {euro, "1234567.891", "1.234.567,89 €"},
+		8:  {euro, "-12.3", "-12,30 €"},
+		9:  {inr, "1234567.891", "12,34,567.89"},
+		10: {inr, "123", "123"},
+		11: {inr, "1234", "1,234"},
+		12: {es, "1234", "1234"},
+		13: {es, "12345", "12.345"},
+		14: {es, "1234567", "1.234.567"},
+		15: {fr, "1234567", "1 234 567,0"},
+		16: {fr, "0.12345", "0,123"},
+		17: {sv, "-1234", "−1 234"},
+		18: {pad, "12.34", "00,012.3"},
+		19: {plain, "1234567.5", "1234567.5"},
+		20: {plain, "1E+3", "1000"},
+		21: {plain, "0.00001", "0"},
+		22: {plain, "Inf", "Inf"},
+		23: {plain, "-Inf", "-Inf"},
+		24: {plain, "NaN", "NaN"},
+		25: {down, "1.99", "1.9"},
+		26: {down, "-1.99", "-1.9"},
+		27: {money, "123456789012345678901234567890.125", "$123,456,789,012,345,678,901,234,567,890.12"},
+	} {
+		got := test.p.Format(newbig(t, test.x))
+		if got != test.want {
+			t.Fatalf(`#%d: Format(%s)
+wanted: %q
+got   : %q
+`, i, test.x, test.want, got)
+		}
+	}
+}
+
+func TestPattern_Append(t *testing.T) {
+	p := Pattern{Locale: EnUS, MaxFrac: 2}
+	x := newbig(t, "1234.5")
+	got := string(p.Append([]byte("total: "), x))
+	if want := "total: 1,234.5"; got != want {
+		t.Fatalf("wanted %q, got %q", want, got)
+	}
+	if x.String() != "1234.5" {
+		t.Fatalf("Append modified its argument: %s", x)
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	for i, test := range [...]struct {
+		tag  string
+		want string // Decimal
+		ok   bool
+	}{
+		0: {"en-US", ".", true},
+		1: {"de_DE", ",", true},
+		2: {"FR-fr", ",", true},
+		3: {"xx-YY", ".", false},
+	} {
+		loc, ok := LookupLocale(test.tag)
+		if ok != test.ok || loc.Decimal != test.want {
+			t.Fatalf("#%d: LookupLocale(%q): wanted (%q, %t), got (%q, %t)",
+				i, test.tag, test.want, test.ok, loc.Decimal, ok)
+		}
+	}
+}
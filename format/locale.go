@@ -0,0 +1,66 @@
+package format
+
+import "strings"
+
+// Locale holds the symbols and digit grouping a locale uses for numbers.
+type Locale struct {
+	// Decimal separates the integer and fractional parts of a number.
+	Decimal string
+
+	// Group separates groups of integer digits.
+	Group string
+
+	// Grouping is the size of each group of integer digits, starting from the
+	// radix. The last size is repeated. For example, {3} groups 1234567 as
+	// 1,234,567 and {3, 2} groups it as 12,34,567. If Grouping is empty,
+	// digits are not grouped.
+	Grouping []int
+
+	// MinGrouping is the minimum number of digits that must precede the first
+	// group separator. For example, with a MinGrouping of 2, 1234 is not
+	// grouped but 12345 is grouped as 12.345. Values less than 1 are treated
+	// as 1.
+	MinGrouping int
+
+	// Minus is the minus sign.
+	Minus string
+
+	// Infinity and NaN are the symbols for infinity and NaN.
+	Infinity, NaN string
+}
+
+// Locales for common language tags.
+var (
+	EnUS = Locale{Decimal: ".", Group: ",", Grouping: []int{3}, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	EnGB = EnUS
+	EnIN = Locale{Decimal: ".", Group: ",", Grouping: []int{3, 2}, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	DeDE = Locale{Decimal: ",", Group: ".", Grouping: []int{3}, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	DeCH = Locale{Decimal: ".", Group: "\u2019", Grouping: []int{3}, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	EsES = Locale{Decimal: ",", Group: ".", Grouping: []int{3}, MinGrouping: 2, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	FrFR = Locale{Decimal: ",", Group: "\u202f", Grouping: []int{3}, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	ItIT = DeDE
+	JaJP = EnUS
+	NlNL = DeDE
+	PlPL = Locale{Decimal: ",", Group: "\u00a0", Grouping: []int{3}, MinGrouping: 2, Minus: "-", Infinity: "∞", NaN: "NaN"}
+	PtBR = DeDE
+	RuRU = Locale{Decimal: ",", Group: "\u00a0", Grouping: []int{3}, Minus: "-", Infinity: "∞", NaN: "не число"}
+	SvSE = Locale{Decimal: ",", Group: "\u00a0", Grouping: []int{3}, Minus: "\u2212", Infinity: "∞", NaN: "NaN"}
+	ZhCN = EnUS
+)
+
+var locales = map[string]*Locale{
+	"en-us": &EnUS, "en-gb": &EnGB, "en-in": &EnIN, "de-de": &DeDE,
+	"de-ch": &DeCH, "es-es": &EsES, "fr-fr": &FrFR, "it-it": &ItIT,
+	"ja-jp": &JaJP, "nl-nl": &NlNL, "pl-pl": &PlPL, "pt-br": &PtBR,
+	"ru-ru": &RuRU, "sv-se": &SvSE, "zh-cn": &ZhCN,
+}
+
+// LookupLocale returns the Locale for the BCP 47 language tag, like "en-US"
+// or "de_DE", and true. If the tag is unknown it returns EnUS and false.
+func LookupLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.Replace(tag, "_", "-", -1))
+	if l, ok := locales[tag]; ok {
+		return *l, true
+	}
+	return EnUS, false
+}
@@ -0,0 +1,308 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// Errors returned by Parser.Parse, wrapped in a *ParseError.
+var (
+	ErrEmpty    = errors.New("empty input")
+	ErrSyntax   = errors.New("invalid syntax")
+	ErrGrouping = errors.New("invalid digit grouping")
+	ErrSign     = errors.New("more than one sign")
+	ErrParens   = errors.New("unbalanced parentheses")
+)
+
+// ParseError records a failed parse.
+type ParseError struct {
+	Input  string // the input
+	Offset int    // byte offset in Input where the error was found
+	Err    error  // the reason the parse failed, like ErrSyntax
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("format: parsing %q at offset %d: %v", e.Input, e.Offset, e.Err)
+}
+
+// Parser parses numbers written by people, like "1.234.567,89",
+// "(1,234.50)", "1 234,5", "12%", or "$1,000.00".
+//
+// A number is its digits, optionally surrounded by affixes and white space.
+// The digits may contain the Locale's Decimal separator and, before that,
+// Group separators. If the Group separator is a space character, any space
+// character is accepted in its place; for example, "1 234" is accepted by FrFR
+// even though its Group separator is a narrow no-break space. Group sizes must
+// match the Locale's Grouping, except the leftmost group which may be shorter,
+// so "1,23" is not accepted by EnUS. The Locale's MinGrouping is not enforced.
+//
+// The affixes are
+//
+//     - a sign: '+', '-', U+2212 (minus sign), or the Locale's Minus, which
+//       may precede or follow the digits
+//     - parentheses surrounding everything else, which make the number
+//       negative
+//     - one of the Parser's currency symbols, which may precede or follow the
+//       digits
+//     - a percent sign following the digits, which divides the number by 100
+//
+// Each affix may appear at most once.
+type Parser struct {
+	Locale
+
+	// Currency holds the currency symbols and codes, like "$" or "EUR",
+	// that may precede or follow a number.
+	Currency []string
+}
+
+// affixes records the affixes that have been read.
+type affixes struct {
+	open, closed bool // parentheses
+	sign, neg    bool
+	currency     bool
+	percent      bool
+}
+
+// Parse parses s and returns the resulting number. If s cannot be parsed it
+// returns a nil *decimal.Big and a *ParseError.
+func (p *Parser) Parse(s string) (*decimal.Big, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, &ParseError{Input: s, Offset: len(s), Err: ErrEmpty}
+	}
+
+	var a affixes
+	i, err := p.affix(s, 0, &a, true)
+	if err != nil {
+		return nil, err
+	}
+	buf, i, err := p.digits(s, i)
+	if err != nil {
+		return nil, err
+	}
+	if i, err = p.affix(s, i, &a, false); err != nil {
+		return nil, err
+	}
+	if i != len(s) {
+		return nil, &ParseError{Input: s, Offset: i, Err: ErrSyntax}
+	}
+	if a.open && !a.closed {
+		return nil, &ParseError{Input: s, Offset: i, Err: ErrParens}
+	}
+
+	if a.neg || a.open {
+		buf = append(buf, '-')
+		copy(buf[1:], buf)
+		buf[0] = '-'
+	}
+	z, ok := new(decimal.Big).SetString(string(buf))
+	if !ok {
+		// Unreachable: buf only holds digits, a sign, and a radix.
+		return nil, &ParseError{Input: s, Err: ErrSyntax}
+	}
+	if a.percent {
+		z.SetScale(z.Scale() + 2)
+	}
+	return z, nil
+}
+
+// affix reads the affixes of s beginning at i and returns the offset of the
+// first byte that isn't part of an affix.
+func (p *Parser) affix(s string, i int, a *affixes, prefix bool) (int, error) {
+	for {
+		i = skipSpace(s, i)
+		if i == len(s) {
+			return i, nil
+		}
+		start := i
+		switch {
+		case prefix && s[i] == '(':
+			if a.open || a.sign {
+				return i, &ParseError{Input: s, Offset: i, Err: ErrParens}
+			}
+			a.open = true
+			i++
+		case !prefix && s[i] == ')':
+			if !a.open || a.closed {
+				return i, &ParseError{Input: s, Offset: i, Err: ErrParens}
+			}
+			a.closed = true
+			i++
+		case !prefix && s[i] == '%':
+			if a.percent || a.closed {
+				return i, &ParseError{Input: s, Offset: i, Err: ErrSyntax}
+			}
+			a.percent = true
+			i++
+		default:
+			if n := p.sign(s[i:]); n > 0 {
+				if a.sign || a.open {
+					return i, &ParseError{Input: s, Offset: i, Err: ErrSign}
+				}
+				a.sign = true
+				a.neg = s[i] != '+'
+				i += n
+			} else if n := p.currency(s[i:]); n > 0 {
+				if a.currency || a.closed {
+					return i, &ParseError{Input: s, Offset: i, Err: ErrSyntax}
+				}
+				a.currency = true
+				i += n
+			} else {
+				return i, nil
+			}
+		}
+		if a.closed && s[start] != ')' {
+			// Nothing but white space may follow a closing parenthesis.
+			return start, &ParseError{Input: s, Offset: start, Err: ErrSyntax}
+		}
+	}
+}
+
+// digits reads the digits of s beginning at i and returns them as a number
+// accepted by decimal.Big.SetString.
+func (p *Parser) digits(s string, i int) ([]byte, int, error) {
+	var (
+		buf   = make([]byte, 0, len(s)+1)
+		ndig  int
+		dot   bool
+		group int   // digits since the last separator
+		sizes []int // sizes of groups preceding a separator
+		seps  []int // offsets of the separators
+	)
+	for i < len(s) {
+		if isDigit(s[i]) {
+			buf = append(buf, s[i])
+			ndig++
+			if !dot {
+				group++
+			}
+			i++
+			continue
+		}
+		if dot {
+			break
+		}
+		if n := prefixLen(s[i:], p.Decimal); n > 0 {
+			buf = append(buf, '.')
+			dot = true
+			i += n
+			continue
+		}
+		// A group separator must be between two digits.
+		if n := p.group(s[i:]); n > 0 && group > 0 && i+n < len(s) && isDigit(s[i+n]) {
+			sizes = append(sizes, group)
+			seps = append(seps, i)
+			group = 0
+			i += n
+			continue
+		}
+		break
+	}
+	if ndig == 0 {
+		return nil, i, &ParseError{Input: s, Offset: i, Err: ErrSyntax}
+	}
+	if len(seps) == 0 {
+		return buf, i, nil
+	}
+
+	if len(p.Grouping) == 0 {
+		return nil, i, &ParseError{Input: s, Offset: seps[0], Err: ErrGrouping}
+	}
+	// group is now the number of digits between the last separator and the
+	// radix. Check each group from the radix leftward.
+	sizes = append(sizes, group)
+	for j := len(sizes) - 1; j >= 0; j-- {
+		want := p.groupSize(len(sizes) - 1 - j)
+		if j == 0 && sizes[j] <= want || sizes[j] == want {
+			continue
+		}
+		off := seps[0]
+		if j > 0 {
+			off = seps[j-1]
+		}
+		return nil, i, &ParseError{Input: s, Offset: off, Err: ErrGrouping}
+	}
+	return buf, i, nil
+}
+
+// groupSize returns the size of the ith group of integer digits, counting
+// from the radix.
+func (p *Parser) groupSize(i int) int {
+	if i < len(p.Grouping) {
+		return p.Grouping[i]
+	}
+	return p.Grouping[len(p.Grouping)-1]
+}
+
+// sign returns the length of the sign at the beginning of s, or 0 if there
+// isn't one.
+func (p *Parser) sign(s string) int {
+	switch {
+	case len(s) == 0:
+		return 0
+	case s[0] == '+', s[0] == '-':
+		return 1
+	case strings.HasPrefix(s, "−"):
+		return len("−")
+	default:
+		return prefixLen(s, p.Minus)
+	}
+}
+
+// currency returns the length of the longest currency symbol at the beginning
+// of s, or 0 if there isn't one.
+func (p *Parser) currency(s string) int {
+	n := 0
+	for _, sym := range p.Currency {
+		if m := prefixLen(s, sym); m > n {
+			n = m
+		}
+	}
+	return n
+}
+
+// group returns the length of the group separator at the beginning of s, or
+// 0 if there isn't one.
+func (p *Parser) group(s string) int {
+	if n := prefixLen(s, p.Group); n > 0 {
+		return n
+	}
+	g, _ := utf8.DecodeRuneInString(p.Group)
+	if p.Group == "" || !unicode.IsSpace(g) {
+		return 0
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	if !unicode.IsSpace(r) {
+		return 0
+	}
+	return n
+}
+
+// prefixLen returns len(prefix) if s begins with prefix and prefix is not
+// empty, and 0 otherwise.
+func prefixLen(s, prefix string) int {
+	if prefix != "" && strings.HasPrefix(s, prefix) {
+		return len(prefix)
+	}
+	return 0
+}
+
+// skipSpace returns the offset of the first non-space character in s at or
+// after i.
+func skipSpace(s string, i int) int {
+	for i < len(s) {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		i += n
+	}
+	return i
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
@@ -0,0 +1,94 @@
+package format
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParser_Parse(t *testing.T) {
+	var (
+		us  = Parser{Locale: EnUS, Currency: []string{"$", "USD"}}
+		de  = Parser{Locale: DeDE, Currency: []string{"€", "EUR"}}
+		fr  = Parser{Locale: FrFR}
+		in  = Parser{Locale: EnIN, Currency: []string{"₹"}}
+		sv  = Parser{Locale: SvSE}
+		raw = Parser{Locale: Locale{Decimal: "."}}
+	)
+	for i, test := range [...]struct {
+		p    Parser
+		s    string
+		want string
+	}{
+		0:  {de, "1.234.567,89", "1234567.89"},
+		1:  {us, "(1,234.50)", "-1234.50"},
+		2:  {fr, "1 234,5", "1234.5"},
+		3:  {fr, "1 234,5", "1234.5"},
+		4:  {us, "12%", "0.12"},
+		5:  {us, "$1,000.00", "1000.00"},
+		6:  {us, "-$1,000", "-1000"},
+		7:  {us, "$-1,000", "-1000"},
+		8:  {us, "1,000 USD", "1000"},
+		9:  {us, "1000-", "-1000"},
+		10: {us, "  +42  ", "42"},
+		11: {us, "(USD 5)", "-5"},
+		12: {us, ".5", "0.5"},
+		13: {us, "5.", "5"},
+		14: {us, "12,345.6%", "123.456"},
+		15: {de, "-1.234 €", "-1234"},
+		16: {in, "₹12,34,567.89", "1234567.89"},
+		17: {sv, "−1 234", "-1234"},
+		18: {raw, "1234567.5", "1234567.5"},
+		19: {us, "1,234,567", "1234567"},
+		20: {us, "0012", "12"},
+	} {
+		got, err := test.p.Parse(test.s)
+		if err != nil {
+			t.Fatalf("#%d: Parse(%q): unexpected error: %v", i, test.s, err)
+		}
+		if want := newbig(t, test.want); got.Cmp(want) != 0 || got.Scale() != want.Scale() {
+			t.Fatalf("#%d: Parse(%q): wanted %s, got %s", i, test.s, want, got)
+		}
+	}
+}
+
+func TestParser_ParseError(t *testing.T) {
+	var (
+		us = Parser{Locale: EnUS, Currency: []string{"$"}}
+		de = Parser{Locale: DeDE}
+		in = Parser{Locale: EnIN}
+	)
+	for i, test := range [...]struct {
+		p      Parser
+		s      string
+		err    error
+		offset int
+	}{
+		0:  {us, "", ErrEmpty, 0},
+		1:  {us, "   ", ErrEmpty, 3},
+		2:  {us, "abc", ErrSyntax, 0},
+		3:  {us, "1,23", ErrGrouping, 1},
+		4:  {us, "12,3456", ErrGrouping, 2},
+		5:  {in, "1,234,567", ErrGrouping, 1},
+		6:  {us, "--1", ErrSign, 1},
+		7:  {us, "-1-", ErrSign, 2},
+		8:  {us, "(1", ErrParens, 2},
+		9:  {us, "1)", ErrParens, 1},
+		10: {us, "-(1)", ErrParens, 1},
+		11: {us, "1.2.3", ErrSyntax, 3},
+		12: {us, "$1$", ErrSyntax, 2},
+		13: {us, "(1) %", ErrSyntax, 4},
+		14: {us, "1%%", ErrSyntax, 2},
+		15: {de, "1,234.5", ErrSyntax, 5},
+		16: {us, "$", ErrSyntax, 1},
+	} {
+		_, err := test.p.Parse(test.s)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("#%d: Parse(%q): wanted *ParseError, got %v", i, test.s, err)
+		}
+		if perr.Err != test.err || perr.Offset != test.offset {
+			t.Fatalf("#%d: Parse(%q): wanted (%v, %d), got (%v, %d)",
+				i, test.s, test.err, test.offset, perr.Err, perr.Offset)
+		}
+	}
+}
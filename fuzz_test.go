@@ -0,0 +1,216 @@
+//go:build go1.18
+// +build go1.18
+
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// fuzzSeeds are the seed corpus shared by the fuzz targets. Many of them sit on
+// the boundary between compact (int64) and inflated (big.Int) decimals.
+var fuzzSeeds = [...]string{
+	"0", "-0", "0.000", "1", "-1", "1.5", "0.1", "123.456e-7", "1E+3",
+	"9223372036854775807",
+	"9223372036854775808",
+	"-9223372036854775808",
+	"-9223372036854775809",
+	"999999999999999999.9",
+	"1000000000000000000",
+	"9999999999999999999",
+	"18446744073709551616e-3",
+	"0.0000000000000000001",
+	"NaN", "sNaN", "Inf", "-Infinity",
+}
+
+// fuzzBig parses s in GDA mode and reports whether it's a valid decimal small
+// enough to fuzz. Huge exponents and coefficients are valid, but only make
+// the fuzzer spend its time allocating.
+func fuzzBig(s string) (*Big, bool) {
+	x, ok := gdaBig(s)
+	if !ok || x.IsFinite() && (x.Precision() > 1000 || x.Scale() > 1000 || x.Scale() < -1000) {
+		return nil, false
+	}
+	return x, true
+}
+
+// gdaBig parses s in GDA mode and reports whether it's a valid decimal.
+func gdaBig(s string) (*Big, bool) {
+	x := new(Big)
+	x.Context.OperatingMode = GDA
+	_, ok := x.SetString(s)
+	return x, ok
+}
+
+func FuzzBig_SetString(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		x, ok := fuzzBig(s)
+		if !ok {
+			return
+		}
+		str := x.String()
+		y, ok := gdaBig(str)
+		if !ok {
+			t.Fatalf("SetString(%q): couldn't parse String() = %q", s, str)
+		}
+		if !sameValue(x, y) {
+			t.Fatalf("SetString(%q): wanted %q, got %q", s, str, y)
+		}
+		if str2 := y.String(); str2 != str {
+			t.Fatalf("SetString(%q): String() = %q, then %q", s, str, str2)
+		}
+	})
+}
+
+func FuzzBig_AddNeg(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		x, ok := fuzzBig(s)
+		if !ok || x.IsNaN(0) || x.IsInf(0) {
+			return
+		}
+		z := new(Big).Add(x, new(Big).Neg(x))
+		if z.Sign() != 0 {
+			t.Fatalf("%s + -%s: wanted 0, got %s", x, x, z)
+		}
+	})
+}
+
+func FuzzBig_QuoMul(f *testing.F) {
+	for i, s := range fuzzSeeds {
+		f.Add(s, fuzzSeeds[(i+7)%len(fuzzSeeds)], uint8(i))
+	}
+	f.Add("1", "1", uint8(6))   // 1.000000, not 1.0000000
+	f.Add("1", "101", uint8(0)) // rounds 0.0099 up to 0.01, not 0.010
+	f.Fuzz(func(t *testing.T, xs, ys string, prec uint8) {
+		x, ok1 := fuzzBig(xs)
+		y, ok2 := fuzzBig(ys)
+		if !ok1 || !ok2 || x.IsNaN(0) || x.IsInf(0) || y.IsNaN(0) || y.IsInf(0) {
+			return
+		}
+		p := int32(prec%50) + 1
+
+		q := new(Big)
+		q.Context.OperatingMode = GDA
+		q.Context.SetPrecision(p)
+		q.Quo(x, y)
+		switch {
+		case x.Sign() == 0 && y.Sign() == 0:
+			if !q.IsNaN(0) || q.Context.Conditions&InvalidOperation == 0 {
+				t.Fatalf("%s / %s: wanted NaN and %s, got %s and %s",
+					x, y, InvalidOperation, q, q.Context.Conditions)
+			}
+			return
+		case y.Sign() == 0:
+			// The infinity's sign is the exclusive or of the operands' signs.
+			sign := +1
+			if x.Signbit() != y.Signbit() {
+				sign = -1
+			}
+			if !q.IsInf(sign) || q.Context.Conditions&DivisionByZero == 0 {
+				t.Fatalf("%s / %s: wanted an infinity and %s, got %s and %s",
+					x, y, DivisionByZero, q, q.Context.Conditions)
+			}
+			return
+		case x.Sign() == 0:
+			if q.Sign() != 0 || q.IsNaN(0) || q.IsInf(0) {
+				t.Fatalf("%s / %s: wanted 0, got %s", x, y, q)
+			}
+			return
+		}
+		if q.IsNaN(0) || q.IsInf(0) {
+			t.Fatalf("%s / %s [prec: %d]: wanted a finite result, got %s", x, y, p, q)
+		}
+		if q.Precision() > int(p) {
+			t.Fatalf("%s / %s [prec: %d]: %s has %d digits", x, y, p, q, q.Precision())
+		}
+
+		m := new(Big)
+		m.Context.OperatingMode = GDA
+		m.Context.SetPrecision(p)
+		m.Mul(q, y)
+
+		// q is within one ulp of x/y, so |q*y - x| <= ulp(q)*|y|, and m is
+		// within one ulp of q*y.
+		bound := new(big.Rat).Mul(fuzzUlp(q, p), new(big.Rat).Abs(y.Rat(nil)))
+		bound.Add(bound, fuzzUlp(m, p))
+		diff := new(big.Rat).Sub(m.Rat(nil), x.Rat(nil))
+		if diff.Abs(diff).Cmp(bound) > 0 {
+			t.Fatalf("%s / %s [prec: %d]: %s * %s = %s is more than an ulp from %s",
+				x, y, p, q, y, m, x)
+		}
+	})
+}
+
+// fuzzUlp returns the unit in the last place of x at prec digits.
+func fuzzUlp(x *Big, prec int32) *big.Rat {
+	return refPow10(int32(x.Precision()) - x.Scale() - prec)
+}
+
+func FuzzBig_MarshalText(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		x, ok := fuzzBig(s)
+		if !ok {
+			return
+		}
+		text, err := x.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%q): %v", s, err)
+		}
+		z := new(Big)
+		z.Context.OperatingMode = GDA
+		if err := z.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", text, err)
+		}
+		if !sameValue(x, z) {
+			t.Fatalf("MarshalText(%q): wanted %q, got %q", s, text, z)
+		}
+	})
+}
+
+func FuzzBig_Format(f *testing.F) {
+	for i, s := range fuzzSeeds {
+		f.Add(s, "sdeEfgGvqxX%z"[i%13], uint8(i), uint8(i%5), uint8(i))
+	}
+	f.Fuzz(func(t *testing.T, s string, verb byte, flags, width, prec uint8) {
+		x, ok := fuzzBig(s)
+		if !ok {
+			return
+		}
+		for _, mode := range [...]OperatingMode{Go, GDA} {
+			x.Context.OperatingMode = mode
+
+			var b strings.Builder
+			b.WriteByte('%')
+			for i, flag := range "+-# 0" {
+				if flags&(1<<uint(i)) != 0 {
+					b.WriteRune(flag)
+				}
+			}
+			if flags&(1<<5) != 0 {
+				fmt.Fprintf(&b, "%d", width%64)
+			}
+			if flags&(1<<6) != 0 {
+				fmt.Fprintf(&b, ".%d", prec%64)
+			}
+			b.WriteByte(verb)
+
+			// fmt recovers panics in Format and reports them in its output.
+			format := b.String()
+			if out := fmt.Sprintf(format, x); strings.Contains(out, "PANIC=") {
+				t.Fatalf("Sprintf(%q, %s) [mode: %s]: %s", format, s, mode, out)
+			}
+		}
+	})
+}
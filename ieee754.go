@@ -0,0 +1,586 @@
+package decimal
+
+import (
+	"math/big"
+
+	"github.com/ericlagergren/decimal/internal/arith/pow"
+)
+
+// The following types hold IEEE 754-2008 decimal interchange formats. Each
+// stores its value using the binary integer decimal (BID) encoding, but can be
+// converted to and from the densely packed decimal (DPD) encoding as well.
+//
+// Arithmetic on the types is performed by converting the operands to Big,
+// computing the result using the corresponding ContextXX, and converting the
+// result back. NaN payloads are not preserved.
+
+// Decimal32 is an IEEE 754-2008 decimal32 value with 7 digits of precision.
+// The zero value is +0E-101.
+type Decimal32 struct{ bits uint32 }
+
+// Decimal64 is an IEEE 754-2008 decimal64 value with 16 digits of precision.
+// The zero value is +0E-398.
+type Decimal64 struct{ bits uint64 }
+
+// Decimal128 is an IEEE 754-2008 decimal128 value with 34 digits of
+// precision. The zero value is +0E-6176.
+type Decimal128 struct{ hi, lo uint64 }
+
+// Decimal32FromBID returns the Decimal32 with the BID encoding bits.
+func Decimal32FromBID(bits uint32) Decimal32 { return Decimal32{bits} }
+
+// Decimal32FromDPD returns the Decimal32 with the DPD encoding bits.
+func Decimal32FromDPD(bits uint32) Decimal32 {
+	return Decimal32{uint32(dec32.bid(dec32.fromDPD(u128{lo: uint64(bits)})).lo)}
+}
+
+// BID returns the BID encoding of x.
+func (x Decimal32) BID() uint32 { return x.bits }
+
+// DPD returns the DPD encoding of x.
+func (x Decimal32) DPD() uint32 {
+	return uint32(dec32.dpd(dec32.fromBID(u128{lo: uint64(x.bits)})).lo)
+}
+
+// Decimal64FromBID returns the Decimal64 with the BID encoding bits.
+func Decimal64FromBID(bits uint64) Decimal64 { return Decimal64{bits} }
+
+// Decimal64FromDPD returns the Decimal64 with the DPD encoding bits.
+func Decimal64FromDPD(bits uint64) Decimal64 {
+	return Decimal64{dec64.bid(dec64.fromDPD(u128{lo: bits})).lo}
+}
+
+// BID returns the BID encoding of x.
+func (x Decimal64) BID() uint64 { return x.bits }
+
+// DPD returns the DPD encoding of x.
+func (x Decimal64) DPD() uint64 {
+	return dec64.dpd(dec64.fromBID(u128{lo: x.bits})).lo
+}
+
+// Decimal128FromBID returns the Decimal128 with the BID encoding whose high
+// and low 64 bits are hi and lo, respectively.
+func Decimal128FromBID(hi, lo uint64) Decimal128 { return Decimal128{hi, lo} }
+
+// Decimal128FromDPD returns the Decimal128 with the DPD encoding whose high
+// and low 64 bits are hi and lo, respectively.
+func Decimal128FromDPD(hi, lo uint64) Decimal128 {
+	b := dec128.bid(dec128.fromDPD(u128{hi, lo}))
+	return Decimal128{b.hi, b.lo}
+}
+
+// BID returns the high and low 64 bits of the BID encoding of x.
+func (x Decimal128) BID() (hi, lo uint64) { return x.hi, x.lo }
+
+// DPD returns the high and low 64 bits of the DPD encoding of x.
+func (x Decimal128) DPD() (hi, lo uint64) {
+	b := dec128.dpd(dec128.fromBID(u128{x.hi, x.lo}))
+	return b.hi, b.lo
+}
+
+// SetDecimal32 sets z to x and returns z.
+func (z *Big) SetDecimal32(x Decimal32) *Big {
+	return z.setIEEE(dec32.fromBID(u128{lo: uint64(x.bits)}))
+}
+
+// SetDecimal64 sets z to x and returns z.
+func (z *Big) SetDecimal64(x Decimal64) *Big {
+	return z.setIEEE(dec64.fromBID(u128{lo: x.bits}))
+}
+
+// SetDecimal128 sets z to x and returns z.
+func (z *Big) SetDecimal128(x Decimal128) *Big {
+	return z.setIEEE(dec128.fromBID(u128{x.hi, x.lo}))
+}
+
+// Decimal32 returns x rounded to a Decimal32 using Context32. Values too large
+// to be represented become infinities and values too small become zeros or
+// subnormals.
+func (x *Big) Decimal32() Decimal32 {
+	return Decimal32{uint32(dec32.bid(dec32.parts(x)).lo)}
+}
+
+// Decimal64 returns x rounded to a Decimal64 using Context64. Values too large
+// to be represented become infinities and values too small become zeros or
+// subnormals.
+func (x *Big) Decimal64() Decimal64 {
+	return Decimal64{dec64.bid(dec64.parts(x)).lo}
+}
+
+// Decimal128 returns x rounded to a Decimal128 using Context128. Values too
+// large to be represented become infinities and values too small become zeros
+// or subnormals.
+func (x *Big) Decimal128() Decimal128 {
+	b := dec128.bid(dec128.parts(x))
+	return Decimal128{b.hi, b.lo}
+}
+
+// u128 is an unsigned 128-bit integer used to hold the encodings.
+type u128 struct{ hi, lo uint64 }
+
+// field returns the n <= 64 bits of x starting at bit off.
+func (x u128) field(off, n uint) uint64 {
+	var v uint64
+	switch {
+	case off >= 64:
+		v = x.hi >> (off - 64)
+	case off == 0:
+		v = x.lo
+	default:
+		v = x.lo>>off | x.hi<<(64-off)
+	}
+	if n < 64 {
+		v &= 1<<n - 1
+	}
+	return v
+}
+
+// setField returns x with the bits of v set starting at bit off. The bits of
+// x at the same positions must be zero.
+func (x u128) setField(off uint, v uint64) u128 {
+	switch {
+	case off >= 64:
+		x.hi |= v << (off - 64)
+	case off == 0:
+		x.lo |= v
+	default:
+		x.lo |= v << off
+		x.hi |= v >> (64 - off)
+	}
+	return x
+}
+
+// ieeeFormat describes one of the IEEE 754-2008 decimal interchange formats.
+type ieeeFormat struct {
+	k    uint  // storage width in bits
+	p    int32 // precision in digits
+	w    uint  // exponent continuation bits
+	bias int32
+	ctx  Context
+}
+
+var (
+	dec32  = &ieeeFormat{k: 32, p: 7, w: 6, bias: 101, ctx: Context32}
+	dec64  = &ieeeFormat{k: 64, p: 16, w: 8, bias: 398, ctx: Context64}
+	dec128 = &ieeeFormat{k: 128, p: 34, w: 12, bias: 6176, ctx: Context128}
+)
+
+// ebits returns the width of the biased exponent.
+func (f *ieeeFormat) ebits() uint { return f.w + 2 }
+
+// t returns the width of the trailing significand field.
+func (f *ieeeFormat) t() uint { return f.k - 6 - f.w }
+
+// qmin and qmax return the smallest and largest exponents of a value's
+// coefficient, respectively.
+func (f *ieeeFormat) qmin() int32 { return -f.bias }
+func (f *ieeeFormat) qmax() int32 { return 3<<f.w - 1 - f.bias }
+
+// ieeeParts is a decoded IEEE 754-2008 decimal.
+type ieeeParts struct {
+	form  form // finite, pinf, qnan, or snan
+	neg   bool
+	q     int32 // unbiased exponent
+	coeff big.Int
+}
+
+// special returns the encoding of the NaN or infinity p.
+func (f *ieeeFormat) special(p *ieeeParts) u128 {
+	var b u128
+	switch p.form {
+	case qnan:
+		b = b.setField(f.k-6, 0x1F)
+	case snan:
+		b = b.setField(f.k-7, 0x3F)
+	default:
+		b = b.setField(f.k-6, 0x1E)
+	}
+	if p.neg {
+		b = b.setField(f.k-1, 1)
+	}
+	return b
+}
+
+// decodeSpecial decodes the NaN or infinity in b into p and reports whether b
+// was a NaN or infinity.
+func (f *ieeeFormat) decodeSpecial(b u128, p *ieeeParts) bool {
+	p.neg = b.field(f.k-1, 1) != 0
+	if b.field(f.k-5, 4) != 0xF {
+		return false
+	}
+	switch {
+	case b.field(f.k-6, 1) == 0:
+		p.form = pinf
+	case b.field(f.k-7, 1) == 0:
+		p.form = qnan
+	default:
+		p.form = snan
+	}
+	return true
+}
+
+// canonical sets p's coefficient to zero if it's too large for f.
+func (f *ieeeFormat) canonical(p *ieeeParts) {
+	if p.coeff.Cmp(pow.BigTen(int64(f.p))) >= 0 {
+		p.coeff.SetInt64(0)
+	}
+}
+
+// setU128 sets z to x and returns z.
+func setU128(z *big.Int, x u128) *big.Int {
+	if x.hi == 0 {
+		return z.SetUint64(x.lo)
+	}
+	z.SetUint64(x.hi).Lsh(z, 64)
+	return z.Or(z, new(big.Int).SetUint64(x.lo))
+}
+
+// fromBID decodes the BID encoding b.
+func (f *ieeeFormat) fromBID(b u128) *ieeeParts {
+	p := new(ieeeParts)
+	if f.decodeSpecial(b, p) {
+		return p
+	}
+	p.form = finite
+
+	var (
+		e     = f.ebits()
+		c     u128
+		cbits uint
+	)
+	if b.field(f.k-3, 2) == 3 {
+		// The significand is 100 followed by the trailing bits.
+		cbits = f.k - 3 - e
+		p.q = int32(b.field(cbits, e)) - f.bias
+		c = u128{}.setField(cbits, 4)
+	} else {
+		cbits = f.k - 1 - e
+		p.q = int32(b.field(cbits, e)) - f.bias
+	}
+	if cbits > 64 {
+		c = c.setField(64, b.field(64, cbits-64))
+		c = c.setField(0, b.lo)
+	} else {
+		c = c.setField(0, b.field(0, cbits))
+	}
+	setU128(&p.coeff, c)
+	f.canonical(p)
+	return p
+}
+
+// fromDPD decodes the DPD encoding b.
+func (f *ieeeFormat) fromDPD(b u128) *ieeeParts {
+	p := new(ieeeParts)
+	if f.decodeSpecial(b, p) {
+		return p
+	}
+	p.form = finite
+
+	var (
+		t    = f.t()
+		g    = b.field(f.k-6, 5)
+		emsb uint64
+		lead uint64
+	)
+	if g>>3 != 3 {
+		emsb, lead = g>>3, g&7
+	} else {
+		emsb, lead = g>>1&3, 8|g&1
+	}
+	p.q = int32(emsb<<f.w|b.field(t, f.w)) - f.bias
+
+	// Accumulate the declets, three digits at a time.
+	n := int(t / 10)
+	if f.k <= 64 {
+		c := lead
+		for i := n - 1; i >= 0; i-- {
+			c = c*1000 + uint64(dpdToBin[b.field(uint(i)*10, 10)])
+		}
+		p.coeff.SetUint64(c)
+	} else {
+		var tmp big.Int
+		p.coeff.SetUint64(lead)
+		for i := n - 1; i >= 0; i-- {
+			p.coeff.Mul(&p.coeff, kilo)
+			p.coeff.Add(&p.coeff, tmp.SetUint64(uint64(dpdToBin[b.field(uint(i)*10, 10)])))
+		}
+	}
+	f.canonical(p)
+	return p
+}
+
+var kilo = big.NewInt(1000)
+
+// bid returns the BID encoding of p.
+func (f *ieeeFormat) bid(p *ieeeParts) u128 {
+	if p.form != finite {
+		return f.special(p)
+	}
+
+	var (
+		b = u128{}
+		e = f.ebits()
+		c = u128{lo: new(big.Int).And(&p.coeff, maxUint64).Uint64()}
+	)
+	if f.k > 64 {
+		c.hi = new(big.Int).Rsh(&p.coeff, 64).Uint64()
+	}
+	if p.neg {
+		b = b.setField(f.k-1, 1)
+	}
+	q := uint64(p.q + f.bias)
+	if cbits := f.k - 1 - e; p.coeff.BitLen() <= int(cbits) {
+		b = b.setField(cbits, q)
+	} else {
+		// The significand is 100 followed by the trailing bits.
+		cbits = f.k - 3 - e
+		b = b.setField(f.k-3, 3)
+		b = b.setField(cbits, q)
+		c.lo &^= 1 << (cbits + 2)
+	}
+	return b.setField(0, c.lo).setField(64, c.hi)
+}
+
+var maxUint64 = new(big.Int).SetUint64(1<<64 - 1)
+
+// dpd returns the DPD encoding of p.
+func (f *ieeeFormat) dpd(p *ieeeParts) u128 {
+	if p.form != finite {
+		return f.special(p)
+	}
+
+	var (
+		t = f.t()
+		n = int(t / 10)
+		b = u128{}
+		q = uint64(p.q + f.bias)
+	)
+	if p.neg {
+		b = b.setField(f.k-1, 1)
+	}
+
+	// Pad the coefficient to p digits, then split it into the leading digit
+	// and declets.
+	digits := p.coeff.String()
+	for len(digits) < int(f.p) {
+		digits = "0" + digits
+	}
+	for i := 0; i < n; i++ {
+		j := len(digits) - 3*(i+1)
+		d := int(digits[j]-'0')*100 + int(digits[j+1]-'0')*10 + int(digits[j+2]-'0')
+		b = b.setField(uint(i)*10, uint64(binToDPD[d]))
+	}
+	lead := uint64(digits[0] - '0')
+
+	var g uint64
+	if emsb := q >> f.w; lead < 8 {
+		g = emsb<<3 | lead
+	} else {
+		g = 0x18 | emsb<<1 | lead&1
+	}
+	b = b.setField(t, q&(1<<f.w-1))
+	return b.setField(f.k-6, g)
+}
+
+// parts returns x rounded to f.
+func (f *ieeeFormat) parts(x *Big) *ieeeParts {
+	p := new(ieeeParts)
+	p.neg = x.Signbit()
+	switch {
+	case x.form&nan != 0:
+		p.form = x.form & nan
+		return p
+	case x.form&inf != 0:
+		p.form = pinf
+		return p
+	}
+	p.form = finite
+
+	z := new(Big)
+	if x.form == finite && int64(x.Precision())-int64(x.scale)-int64(f.p) < int64(f.qmin()) {
+		// Subnormal: round once, straight to the smallest exponent, instead
+		// of to p digits first.
+		z.Copy(x)
+		z.Context = f.ctx
+		z.Quantize(-f.qmin())
+	} else {
+		z.Context = f.ctx
+		z.Set(x)
+		if z.form == finite && z.Precision() > int(f.p) {
+			// Rounding carried into a new digit, e.g. 9.9999995 -> 10.000000.
+			z.Quantize(z.scale - 1)
+		}
+	}
+
+	q := -int64(z.scale)
+	if z.form <= nzero {
+		// Zeros keep their exponent, clamped to the format's range.
+		switch {
+		case q < int64(f.qmin()):
+			p.q = f.qmin()
+		case q > int64(f.qmax()):
+			p.q = f.qmax()
+		default:
+			p.q = int32(q)
+		}
+		return p
+	}
+
+	if qmax := int64(f.qmax()); q > qmax {
+		// Pad the coefficient with zeros if it fits, otherwise overflow.
+		if int64(z.Precision())+q-qmax > int64(f.p) {
+			p.form = pinf
+			return p
+		}
+		z.Quantize(int32(-qmax))
+		q = qmax
+	}
+	p.q = int32(q)
+	if z.form > nzero {
+		if z.isCompact() {
+			p.coeff.SetInt64(z.compact)
+		} else {
+			p.coeff.Set(&z.unscaled)
+		}
+		p.coeff.Abs(&p.coeff)
+	}
+	return p
+}
+
+// setIEEE sets z to p and returns z.
+func (z *Big) setIEEE(p *ieeeParts) *Big {
+	switch p.form {
+	case qnan, snan:
+		z.form = p.form
+		return z
+	case pinf:
+		return z.SetInf(p.neg)
+	}
+
+	if p.coeff.Sign() == 0 {
+		z.compact = 0
+		z.form = zero
+		if p.neg {
+			z.form = nzero
+		}
+	} else {
+		if p.coeff.IsInt64() {
+			z.SetMantScale(p.coeff.Int64(), 0)
+		} else {
+			z.SetBigMantScale(&p.coeff, 0)
+		}
+		if p.neg {
+			z.Neg(z)
+		}
+	}
+	z.scale = -p.q
+	return z
+}
+
+// binary32 returns op(x, y) computed using Context32.
+func binary32(x, y Decimal32, op func(z, x, y *Big) *Big) Decimal32 {
+	var a, b Big
+	a.Context = Context32
+	a.SetDecimal32(x)
+	b.SetDecimal32(y)
+	return op(&a, &a, &b).Decimal32()
+}
+
+// Add returns x + y.
+func (x Decimal32) Add(y Decimal32) Decimal32 { return binary32(x, y, (*Big).Add) }
+
+// Sub returns x - y.
+func (x Decimal32) Sub(y Decimal32) Decimal32 { return binary32(x, y, (*Big).Sub) }
+
+// Mul returns x * y.
+func (x Decimal32) Mul(y Decimal32) Decimal32 { return binary32(x, y, (*Big).Mul) }
+
+// Quo returns x / y.
+func (x Decimal32) Quo(y Decimal32) Decimal32 { return binary32(x, y, (*Big).Quo) }
+
+// Neg returns -x.
+func (x Decimal32) Neg() Decimal32 { return Decimal32{x.bits ^ 1<<31} }
+
+// Cmp compares x and y like Big.Cmp.
+func (x Decimal32) Cmp(y Decimal32) int {
+	return new(Big).SetDecimal32(x).Cmp(new(Big).SetDecimal32(y))
+}
+
+// String implements fmt.Stringer.
+func (x Decimal32) String() string {
+	z := new(Big)
+	z.Context = Context32
+	return z.SetDecimal32(x).String()
+}
+
+// binary64 returns op(x, y) computed using Context64.
+func binary64(x, y Decimal64, op func(z, x, y *Big) *Big) Decimal64 {
+	var a, b Big
+	a.Context = Context64
+	a.SetDecimal64(x)
+	b.SetDecimal64(y)
+	return op(&a, &a, &b).Decimal64()
+}
+
+// Add returns x + y.
+func (x Decimal64) Add(y Decimal64) Decimal64 { return binary64(x, y, (*Big).Add) }
+
+// Sub returns x - y.
+func (x Decimal64) Sub(y Decimal64) Decimal64 { return binary64(x, y, (*Big).Sub) }
+
+// Mul returns x * y.
+func (x Decimal64) Mul(y Decimal64) Decimal64 { return binary64(x, y, (*Big).Mul) }
+
+// Quo returns x / y.
+func (x Decimal64) Quo(y Decimal64) Decimal64 { return binary64(x, y, (*Big).Quo) }
+
+// Neg returns -x.
+func (x Decimal64) Neg() Decimal64 { return Decimal64{x.bits ^ 1<<63} }
+
+// Cmp compares x and y like Big.Cmp.
+func (x Decimal64) Cmp(y Decimal64) int {
+	return new(Big).SetDecimal64(x).Cmp(new(Big).SetDecimal64(y))
+}
+
+// String implements fmt.Stringer.
+func (x Decimal64) String() string {
+	z := new(Big)
+	z.Context = Context64
+	return z.SetDecimal64(x).String()
+}
+
+// binary128 returns op(x, y) computed using Context128.
+func binary128(x, y Decimal128, op func(z, x, y *Big) *Big) Decimal128 {
+	var a, b Big
+	a.Context = Context128
+	a.SetDecimal128(x)
+	b.SetDecimal128(y)
+	return op(&a, &a, &b).Decimal128()
+}
+
+// Add returns x + y.
+func (x Decimal128) Add(y Decimal128) Decimal128 { return binary128(x, y, (*Big).Add) }
+
+// Sub returns x - y.
+func (x Decimal128) Sub(y Decimal128) Decimal128 { return binary128(x, y, (*Big).Sub) }
+
+// Mul returns x * y.
+func (x Decimal128) Mul(y Decimal128) Decimal128 { return binary128(x, y, (*Big).Mul) }
+
+// Quo returns x / y.
+func (x Decimal128) Quo(y Decimal128) Decimal128 { return binary128(x, y, (*Big).Quo) }
+
+// Neg returns -x.
+func (x Decimal128) Neg() Decimal128 { return Decimal128{x.hi ^ 1<<63, x.lo} }
+
+// Cmp compares x and y like Big.Cmp.
+func (x Decimal128) Cmp(y Decimal128) int {
+	return new(Big).SetDecimal128(x).Cmp(new(Big).SetDecimal128(y))
+}
+
+// String implements fmt.Stringer.
+func (x Decimal128) String() string {
+	z := new(Big)
+	z.Context = Context128
+	return z.SetDecimal128(x).String()
+}
@@ -0,0 +1,176 @@
+package decimal
+
+import "testing"
+
+func TestDeclets(t *testing.T) {
+	for i := uint16(0); i < 1000; i++ {
+		if d := dpdToBin[binToDPD[i]]; d != i {
+			t.Fatalf("%d: round trip produced %d", i, d)
+		}
+	}
+	for i, d := range dpdToBin {
+		if d > 999 {
+			t.Fatalf("%#x: decoded to %d", i, d)
+		}
+	}
+}
+
+func TestDecimal32(t *testing.T) {
+	for i, test := range [...]struct {
+		in       string
+		bid, dpd uint32
+	}{
+		0: {"1", 0x32800001, 0x22500001},
+		1: {"-7.50", 0xB18002EE, 0xA23003D0},
+		2: {"9.999999E+96", 0x77F8967F, 0x77F3FCFF},
+		3: {"Infinity", 0x78000000, 0x78000000},
+		4: {"-Infinity", 0xF8000000, 0xF8000000},
+		5: {"NaN", 0x7C000000, 0x7C000000},
+		6: {"sNaN", 0x7E000000, 0x7E000000},
+	} {
+		x := ieeeBig(t, test.in)
+		d := x.Decimal32()
+		if d.BID() != test.bid {
+			t.Fatalf("#%d: BID: wanted %#x, got %#x", i, test.bid, d.BID())
+		}
+		if d.DPD() != test.dpd {
+			t.Fatalf("#%d: DPD: wanted %#x, got %#x", i, test.dpd, d.DPD())
+		}
+		if d2 := Decimal32FromDPD(test.dpd); d2 != d {
+			t.Fatalf("#%d: FromDPD: wanted %#x, got %#x", i, d.BID(), d2.BID())
+		}
+		if x.IsNaN(0) {
+			continue
+		}
+		if y := new(Big).SetDecimal32(d); y.Cmp(x) != 0 {
+			t.Fatalf("#%d: wanted %s, got %s", i, x, y)
+		}
+	}
+}
+
+func TestDecimal64(t *testing.T) {
+	for i, test := range [...]struct {
+		in       string
+		bid, dpd uint64
+	}{
+		0: {"1", 0x31C0000000000001, 0x2238000000000001},
+		1: {"-7.50", 0xB1800000000002EE, 0xA2300000000003D0},
+		2: {"9.999999999999999E+384", 0x77FB86F26FC0FFFF, 0x77FCFF3FCFF3FCFF},
+		3: {"Infinity", 0x7800000000000000, 0x7800000000000000},
+		4: {"NaN", 0x7C00000000000000, 0x7C00000000000000},
+	} {
+		x := ieeeBig(t, test.in)
+		d := x.Decimal64()
+		if d.BID() != test.bid {
+			t.Fatalf("#%d: BID: wanted %#x, got %#x", i, test.bid, d.BID())
+		}
+		if d.DPD() != test.dpd {
+			t.Fatalf("#%d: DPD: wanted %#x, got %#x", i, test.dpd, d.DPD())
+		}
+		if d2 := Decimal64FromDPD(test.dpd); d2 != d {
+			t.Fatalf("#%d: FromDPD: wanted %#x, got %#x", i, d.BID(), d2.BID())
+		}
+		if x.IsNaN(0) {
+			continue
+		}
+		if y := new(Big).SetDecimal64(d); y.Cmp(x) != 0 {
+			t.Fatalf("#%d: wanted %s, got %s", i, x, y)
+		}
+	}
+}
+
+func TestDecimal128(t *testing.T) {
+	for i, test := range [...]struct {
+		in       string
+		bid, dpd [2]uint64
+	}{
+		0: {"1", [2]uint64{0x3040000000000000, 1}, [2]uint64{0x2208000000000000, 1}},
+		1: {"-7.50", [2]uint64{0xB03C000000000000, 0x2EE}, [2]uint64{0xA207800000000000, 0x3D0}},
+		2: {"0.001234", [2]uint64{0x3034000000000000, 0x4D2}, [2]uint64{0x2206800000000000, 0x534}},
+		3: {"9.999999999999999999999999999999999E+6144",
+			[2]uint64{0x5FFFED09BEAD87C0, 0x378D8E63FFFFFFFF},
+			[2]uint64{0x77FFCFF3FCFF3FCF, 0xF3FCFF3FCFF3FCFF}},
+		4: {"-Infinity", [2]uint64{0xF800000000000000, 0}, [2]uint64{0xF800000000000000, 0}},
+	} {
+		x := ieeeBig(t, test.in)
+		d := x.Decimal128()
+		if hi, lo := d.BID(); hi != test.bid[0] || lo != test.bid[1] {
+			t.Fatalf("#%d: BID: wanted %#x, got %#x", i, test.bid, [2]uint64{hi, lo})
+		}
+		if hi, lo := d.DPD(); hi != test.dpd[0] || lo != test.dpd[1] {
+			t.Fatalf("#%d: DPD: wanted %#x, got %#x", i, test.dpd, [2]uint64{hi, lo})
+		}
+		if d2 := Decimal128FromDPD(test.dpd[0], test.dpd[1]); d2 != d {
+			t.Fatalf("#%d: FromDPD: wanted %v, got %v", i, d, d2)
+		}
+		if y := new(Big).SetDecimal128(d); y.Cmp(x) != 0 {
+			t.Fatalf("#%d: wanted %s, got %s", i, x, y)
+		}
+	}
+}
+
+func TestDecimal32_Round(t *testing.T) {
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		0: {"1.23456789", "1.234568"},
+		1: {"9.9999995", "10.00000"},
+		2: {"1E+96", "1E+96"},
+		3: {"1E+97", "Infinity"},
+		4: {"-1E+97", "-Infinity"},
+		5: {"1.5E-101", "2E-101"},
+		6: {"1E-102", "0"},
+		7: {"1.234567E-99", "1.23E-99"},
+		// Subnormals are only rounded once.
+		8: {"2.50000001E-101", "3E-101"},
+		9: {"1.2349999E-99", "1.23E-99"},
+	} {
+		d := newbig(t, test.in).Decimal32()
+		want := newbig(t, test.out)
+		got := new(Big).SetDecimal32(d)
+		if want.IsInf(0) {
+			if !got.IsInf(want.Sign()) {
+				t.Fatalf("#%d: wanted %s, got %s", i, want, got)
+			}
+			continue
+		}
+		if got.Cmp(want) != 0 {
+			t.Fatalf("#%d: wanted %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestDecimal64_Arithmetic(t *testing.T) {
+	one := New(1, 0).Decimal64()
+	two := New(2, 0).Decimal64()
+	three := New(3, 0).Decimal64()
+	for i, test := range [...]struct {
+		got  Decimal64
+		want string
+	}{
+		0: {one.Add(two), "3"},
+		1: {one.Sub(three), "-2"},
+		2: {two.Mul(three), "6"},
+		3: {one.Quo(three), "0.3333333333333333"},
+		4: {two.Neg(), "-2"},
+	} {
+		if got := new(Big).SetDecimal64(test.got); got.Cmp(newbig(t, test.want)) != 0 {
+			t.Fatalf("#%d: wanted %s, got %s", i, test.want, got)
+		}
+	}
+	if one.Cmp(two) != -1 {
+		t.Fatal("1 < 2")
+	}
+	if s := one.Quo(three).String(); s != "0.3333333333333333" {
+		t.Fatalf("wanted 0.3333333333333333, got %s", s)
+	}
+}
+
+// ieeeBig is like newbig but also accepts NaNs.
+func ieeeBig(t *testing.T, s string) *Big {
+	x, ok := new(Big).SetString(s)
+	if !ok {
+		t.Fatalf("invalid number: %q", s)
+	}
+	return x
+}
@@ -8,6 +8,12 @@ func Abs(x int64) int64 {
 	return (x + mask) ^ mask
 }
 
+// absUint64 returns |x|. Unlike Abs, it's correct for math.MinInt64.
+func absUint64(x int64) uint64 {
+	m := x >> 63
+	return uint64((x ^ m) - m)
+}
+
 // BigAbs returns |x|.
 func BigAbs(x *big.Int) *big.Int {
 	return new(big.Int).Abs(x)
@@ -21,12 +27,12 @@ func bigAbsAlias(x *big.Int) *big.Int {
 
 // AbsCmp compares |x| and |y|
 func AbsCmp(x, y int64) int {
-	x = Abs(x)
-	y = Abs(y)
-	if x > y {
+	// Compare as uint64s since Abs(math.MinInt64) overflows.
+	x0, y0 := absUint64(x), absUint64(y)
+	if x0 > y0 {
 		return +1
 	}
-	if x == y {
+	if x0 == y0 {
 		return 0
 	}
 	return -1
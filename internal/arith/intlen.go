@@ -1,6 +1,7 @@
 package arith
 
 import (
+	"math"
 	"math/big"
 
 	"github.com/ericlagergren/decimal/internal/arith/pow"
@@ -8,6 +9,10 @@ import (
 
 // Length returns the number of digits in x.
 func Length(x int64) int {
+	if x == math.MinInt64 {
+		// Abs(x) overflows.
+		return 19
+	}
 	if x = Abs(x); x < 10 {
 		return 1
 	}
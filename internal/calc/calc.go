@@ -0,0 +1,36 @@
+// Package calc provides helpers for intermediate calculations shared by the
+// math, finance, and money packages.
+package calc
+
+import "github.com/ericlagergren/decimal"
+
+// GuardDigits is the number of extra digits of precision used during
+// intermediate calculations.
+const GuardDigits = 5
+
+// Work returns a decimal suitable for intermediate calculations at prec
+// precision.
+func Work(prec int32) *decimal.Big {
+	z := new(decimal.Big)
+	z.Context.OperatingMode = decimal.GDA
+	z.Context.SetPrecision(prec)
+	return z
+}
+
+// Adjusted returns the adjusted exponent of x. That is, the exponent of x
+// when x is written in scientific notation.
+func Adjusted(x *decimal.Big) int64 {
+	return int64(x.Precision()) - int64(x.Scale()) - 1
+}
+
+// Negligible returns true if adding x to y would not change y at prec
+// precision.
+func Negligible(x, y *decimal.Big, prec int32) bool {
+	if x.Sign() == 0 {
+		return true
+	}
+	if y.Sign() == 0 {
+		return false
+	}
+	return Adjusted(x) < Adjusted(y)-int64(prec)
+}
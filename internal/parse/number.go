@@ -2,7 +2,13 @@
 //line number.rl:1
 package parse
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ericlagergren/decimal/internal/arith/pow"
+)
 
 type Special uint8
 
@@ -12,6 +18,7 @@ const (
 	SNaN 
 	PInf
 	NInf
+	Finite
 )
 
 func (s Special) String() string {
@@ -21,28 +28,66 @@ func (s Special) String() string {
 	case SNaN:    return "sNaN"
 	case PInf:    return "+inf"
 	case NInf:    return "-inf"
+	case Finite:  return "finite"
 	default:      panic(fmt.Sprintf("unknown(%d)", s))
 	}
 }
 
-func ParseSpecial(data string) Special {
+// Number is a numeric string parsed by ParseNumber.
+type Number struct {
+	// Form is Finite, QNaN, SNaN, PInf, or NInf, or Invalid if the string is
+	// not a numeric string.
+	Form Special
+
+	// Neg is true if the string has a leading '-'.
+	Neg bool
+
+	// Compact is the coefficient if Inflated is false. Otherwise, the
+	// coefficient was stored in the *big.Int passed to ParseNumber.
+	Compact  uint64
+	Inflated bool
+
+	// Scale is the number of digits following the radix less the exponent.
+	// It's not bounds checked.
+	Scale int64
+
+	// Off is the offset of the first byte that does not match the grammar if
+	// Form is Invalid, or len(data) if data ends too early.
+	Off int
+}
+
+// maxExp bounds the exponent while it's being parsed. Larger exponents are
+// always out of range, so the remaining digits are ignored.
+const maxExp = math.MaxInt64 / 100
+
+// ParseNumber parses data, which must match the numeric string grammar from
+// the General Decimal Arithmetic Specification, in a single pass. Infinity and
+// NaN are case-insensitive and digits following a NaN are ignored. If the
+// coefficient does not fit into a uint64 it's stored in z.
+func ParseNumber(data string, z *big.Int) (n Number) {
 	cs, p, pe := 0, 0, len(data)
 
+	var (
+		c    coeff
+		exp  int64
+		eneg bool
+	)
+
 	
-//line number.go:33
-const parser_start int = 1
-const parser_first_final int = 22
-const parser_error int = 0
+//line number.go:74
+const number_start int = 1
+const number_first_final int = 18
+const number_error int = 0
 
-const parser_en_main int = 1
+const number_en_main int = 1
 
 
-//line number.go:41
+//line number.go:82
 	{
-	cs = parser_start
+	cs = number_start
 	}
 
-//line number.go:46
+//line number.go:87
 	{
 	if p == pe {
 		goto _test_eof
@@ -56,32 +101,38 @@ const parser_en_main int = 1
 		goto st_case_2
 	case 3:
 		goto st_case_3
+	case 18:
+		goto st_case_18
 	case 4:
 		goto st_case_4
-	case 22:
-		goto st_case_22
 	case 5:
 		goto st_case_5
+	case 19:
+		goto st_case_19
+	case 20:
+		goto st_case_20
 	case 6:
 		goto st_case_6
 	case 7:
 		goto st_case_7
+	case 21:
+		goto st_case_21
 	case 8:
 		goto st_case_8
-	case 23:
-		goto st_case_23
 	case 9:
 		goto st_case_9
 	case 10:
 		goto st_case_10
 	case 11:
 		goto st_case_11
-	case 24:
-		goto st_case_24
+	case 22:
+		goto st_case_22
 	case 12:
 		goto st_case_12
 	case 13:
 		goto st_case_13
+	case 23:
+		goto st_case_23
 	case 14:
 		goto st_case_14
 	case 15:
@@ -90,18 +141,8 @@ const parser_en_main int = 1
 		goto st_case_16
 	case 17:
 		goto st_case_17
-	case 25:
-		goto st_case_25
-	case 18:
-		goto st_case_18
-	case 19:
-		goto st_case_19
-	case 20:
-		goto st_case_20
-	case 21:
-		goto st_case_21
-	case 26:
-		goto st_case_26
+	case 24:
+		goto st_case_24
 	}
 	goto st_out
 	st_case_1:
@@ -109,39 +150,66 @@ const parser_en_main int = 1
 		case 43:
 			goto st2
 		case 45:
-			goto st9
-		case 73:
+			goto tr2
+		case 46:
 			goto st3
+		case 73:
+			goto st6
 		case 78:
-			goto st16
+			goto st12
 		case 81:
-			goto st18
+			goto st14
 		case 83:
-			goto st19
+			goto st15
 		case 105:
-			goto st3
+			goto st6
 		case 110:
-			goto st16
+			goto st12
 		case 113:
-			goto st18
+			goto st14
 		case 115:
-			goto st19
+			goto st15
+		}
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr4
 		}
 		goto st0
 st_case_0:
 	st0:
 		cs = 0
 		goto _out
+tr2:
+//line number.rl:73
+ n.Neg = true 
+	goto st2
 	st2:
 		if p++; p == pe {
 			goto _test_eof2
 		}
 	st_case_2:
+//line number.go:187
 		switch data[p] {
-		case 73:
+		case 46:
 			goto st3
+		case 73:
+			goto st6
+		case 78:
+			goto st12
+		case 81:
+			goto st14
+		case 83:
+			goto st15
 		case 105:
-			goto st3
+			goto st6
+		case 110:
+			goto st12
+		case 113:
+			goto st14
+		case 115:
+			goto st15
+		}
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr4
 		}
 		goto st0
 	st3:
@@ -149,12 +217,31 @@ st_case_0:
 			goto _test_eof3
 		}
 	st_case_3:
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr23
+		}
+		goto st0
+tr23:
+//line number.rl:74
+ c.digit(z, data[p]) 
+//line number.rl:75
+ n.Scale++ 
+	goto st18
+	st18:
+		if p++; p == pe {
+			goto _test_eof18
+		}
+	st_case_18:
+//line number.go:232
 		switch data[p] {
-		case 78:
+		case 69:
 			goto st4
-		case 110:
+		case 101:
 			goto st4
 		}
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr23
+		}
 		goto st0
 	st4:
 		if p++; p == pe {
@@ -162,39 +249,63 @@ st_case_0:
 		}
 	st_case_4:
 		switch data[p] {
-		case 70:
-			goto tr8
-		case 102:
-			goto tr8
-		}
-		goto st0
-tr8:
-//line number.rl:36
- return PInf 
-	goto st22
-	st22:
-		if p++; p == pe {
-			goto _test_eof22
-		}
-	st_case_22:
-//line number.go:181
-		switch data[p] {
-		case 73:
-			goto st5
-		case 105:
+		case 43:
 			goto st5
+		case 45:
+			goto tr28
+		}
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr29
 		}
 		goto st0
+tr28:
+//line number.rl:76
+ eneg = true 
+	goto st5
 	st5:
 		if p++; p == pe {
 			goto _test_eof5
 		}
 	st_case_5:
+//line number.go:267
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr29
+		}
+		goto st0
+tr29:
+//line number.rl:77
+ if exp < maxExp { exp = exp*10 + int64(data[p]-'0') } 
+	goto st19
+	st19:
+		if p++; p == pe {
+			goto _test_eof19
+		}
+	st_case_19:
+//line number.go:281
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr29
+		}
+		goto st0
+tr4:
+//line number.rl:74
+ c.digit(z, data[p]) 
+	goto st20
+	st20:
+		if p++; p == pe {
+			goto _test_eof20
+		}
+	st_case_20:
+//line number.go:295
 		switch data[p] {
-		case 78:
-			goto st6
-		case 110:
-			goto st6
+		case 46:
+			goto st18
+		case 69:
+			goto st4
+		case 101:
+			goto st4
+		}
+		if 48 <= data[p] && data[p] <= 57 {
+			goto tr4
 		}
 		goto st0
 	st6:
@@ -203,9 +314,9 @@ tr8:
 		}
 	st_case_6:
 		switch data[p] {
-		case 73:
+		case 78:
 			goto st7
-		case 105:
+		case 110:
 			goto st7
 		}
 		goto st0
@@ -215,9 +326,26 @@ tr8:
 		}
 	st_case_7:
 		switch data[p] {
-		case 84:
+		case 70:
+			goto tr39
+		case 102:
+			goto tr39
+		}
+		goto st0
+tr39:
+//line number.rl:78
+ n.Form = PInf 
+	goto st21
+	st21:
+		if p++; p == pe {
+			goto _test_eof21
+		}
+	st_case_21:
+//line number.go:341
+		switch data[p] {
+		case 73:
 			goto st8
-		case 116:
+		case 105:
 			goto st8
 		}
 		goto st0
@@ -227,26 +355,11 @@ tr8:
 		}
 	st_case_8:
 		switch data[p] {
-		case 89:
-			goto tr12
-		case 121:
-			goto tr12
-		}
-		goto st0
-tr12:
-//line number.rl:36
- return PInf 
-	goto st23
-tr19:
-//line number.rl:37
- return NInf 
-	goto st23
-	st23:
-		if p++; p == pe {
-			goto _test_eof23
+		case 78:
+			goto st9
+		case 110:
+			goto st9
 		}
-	st_case_23:
-//line number.go:250
 		goto st0
 	st9:
 		if p++; p == pe {
@@ -266,9 +379,9 @@ tr19:
 		}
 	st_case_10:
 		switch data[p] {
-		case 78:
+		case 84:
 			goto st11
-		case 110:
+		case 116:
 			goto st11
 		}
 		goto st0
@@ -278,28 +391,17 @@ tr19:
 		}
 	st_case_11:
 		switch data[p] {
-		case 70:
-			goto tr15
-		case 102:
-			goto tr15
+		case 89:
+			goto st22
+		case 121:
+			goto st22
 		}
 		goto st0
-tr15:
-//line number.rl:37
- return NInf 
-	goto st24
-	st24:
+	st22:
 		if p++; p == pe {
-			goto _test_eof24
-		}
-	st_case_24:
-//line number.go:297
-		switch data[p] {
-		case 73:
-			goto st12
-		case 105:
-			goto st12
+			goto _test_eof22
 		}
+	st_case_22:
 		goto st0
 	st12:
 		if p++; p == pe {
@@ -307,9 +409,9 @@ tr15:
 		}
 	st_case_12:
 		switch data[p] {
-		case 78:
+		case 65:
 			goto st13
-		case 110:
+		case 97:
 			goto st13
 		}
 		goto st0
@@ -319,10 +421,24 @@ tr15:
 		}
 	st_case_13:
 		switch data[p] {
-		case 73:
-			goto st14
-		case 105:
-			goto st14
+		case 78:
+			goto tr53
+		case 110:
+			goto tr53
+		}
+		goto st0
+tr53:
+//line number.rl:79
+ n.Form = QNaN 
+	goto st23
+	st23:
+		if p++; p == pe {
+			goto _test_eof23
+		}
+	st_case_23:
+//line number.go:436
+		if 48 <= data[p] && data[p] <= 57 {
+			goto st23
 		}
 		goto st0
 	st14:
@@ -331,10 +447,10 @@ tr15:
 		}
 	st_case_14:
 		switch data[p] {
-		case 84:
-			goto st15
-		case 116:
-			goto st15
+		case 78:
+			goto st12
+		case 110:
+			goto st12
 		}
 		goto st0
 	st15:
@@ -343,10 +459,10 @@ tr15:
 		}
 	st_case_15:
 		switch data[p] {
-		case 89:
-			goto tr19
-		case 121:
-			goto tr19
+		case 78:
+			goto st16
+		case 110:
+			goto st16
 		}
 		goto st0
 	st16:
@@ -368,119 +484,114 @@ tr15:
 	st_case_17:
 		switch data[p] {
 		case 78:
-			goto tr21
-		case 110:
-			goto tr21
-		}
-		goto st0
-tr21:
-//line number.rl:38
- return QNaN 
-	goto st25
-	st25:
-		if p++; p == pe {
-			goto _test_eof25
-		}
-	st_case_25:
-//line number.go:386
-		if 48 <= data[p] && data[p] <= 57 {
-			goto tr21
-		}
-		goto st0
-	st18:
-		if p++; p == pe {
-			goto _test_eof18
-		}
-	st_case_18:
-		switch data[p] {
-		case 78:
-			goto st16
-		case 110:
-			goto st16
-		}
-		goto st0
-	st19:
-		if p++; p == pe {
-			goto _test_eof19
-		}
-	st_case_19:
-		switch data[p] {
-		case 78:
-			goto st20
-		case 110:
-			goto st20
-		}
-		goto st0
-	st20:
-		if p++; p == pe {
-			goto _test_eof20
-		}
-	st_case_20:
-		switch data[p] {
-		case 65:
-			goto st21
-		case 97:
-			goto st21
-		}
-		goto st0
-	st21:
-		if p++; p == pe {
-			goto _test_eof21
-		}
-	st_case_21:
-		switch data[p] {
-		case 78:
-			goto tr24
+			goto tr62
 		case 110:
-			goto tr24
+			goto tr62
 		}
 		goto st0
-tr24:
-//line number.rl:39
- return SNaN 
-	goto st26
-	st26:
+tr62:
+//line number.rl:80
+ n.Form = SNaN 
+	goto st24
+	st24:
 		if p++; p == pe {
-			goto _test_eof26
+			goto _test_eof24
 		}
-	st_case_26:
-//line number.go:448
+	st_case_24:
+//line number.go:498
 		if 48 <= data[p] && data[p] <= 57 {
-			goto tr24
+			goto st24
 		}
 		goto st0
 	st_out:
 	_test_eof2: cs = 2; goto _test_eof
 	_test_eof3: cs = 3; goto _test_eof
+	_test_eof18: cs = 18; goto _test_eof
 	_test_eof4: cs = 4; goto _test_eof
-	_test_eof22: cs = 22; goto _test_eof
 	_test_eof5: cs = 5; goto _test_eof
+	_test_eof19: cs = 19; goto _test_eof
+	_test_eof20: cs = 20; goto _test_eof
 	_test_eof6: cs = 6; goto _test_eof
 	_test_eof7: cs = 7; goto _test_eof
+	_test_eof21: cs = 21; goto _test_eof
 	_test_eof8: cs = 8; goto _test_eof
-	_test_eof23: cs = 23; goto _test_eof
 	_test_eof9: cs = 9; goto _test_eof
 	_test_eof10: cs = 10; goto _test_eof
 	_test_eof11: cs = 11; goto _test_eof
-	_test_eof24: cs = 24; goto _test_eof
+	_test_eof22: cs = 22; goto _test_eof
 	_test_eof12: cs = 12; goto _test_eof
 	_test_eof13: cs = 13; goto _test_eof
+	_test_eof23: cs = 23; goto _test_eof
 	_test_eof14: cs = 14; goto _test_eof
 	_test_eof15: cs = 15; goto _test_eof
 	_test_eof16: cs = 16; goto _test_eof
 	_test_eof17: cs = 17; goto _test_eof
-	_test_eof25: cs = 25; goto _test_eof
-	_test_eof18: cs = 18; goto _test_eof
-	_test_eof19: cs = 19; goto _test_eof
-	_test_eof20: cs = 20; goto _test_eof
-	_test_eof21: cs = 21; goto _test_eof
-	_test_eof26: cs = 26; goto _test_eof
+	_test_eof24: cs = 24; goto _test_eof
 
 	_test_eof: {}
 	_out: {}
 	}
 
-//line number.rl:45
+//line number.rl:94
+
+
+	if cs < number_first_final {
+		return Number{Off: p}
+	}
+	switch n.Form {
+	case Invalid:
+		n.Form = Finite
+	case PInf:
+		if n.Neg {
+			n.Form = NInf
+		}
+		return n
+	default:
+		return n
+	}
+	if eneg {
+		exp = -exp
+	}
+	n.Scale -= exp
+	n.Compact, n.Inflated = c.finish(z)
+	return n
+}
+
+// coeff accumulates a coefficient 19 digits, the most that always fit into a
+// uint64, at a time.
+type coeff struct {
+	acc      uint64
+	n        int64
+	inflated bool
+}
+
+func (c *coeff) digit(z *big.Int, b byte) {
+	c.acc = c.acc*10 + uint64(b-'0')
+	if c.n++; c.n == 19 {
+		c.flush(z)
+	}
+}
+
+// flush sets z = z * 10^c.n + c.acc or, if c is not yet inflated, z = c.acc.
+func (c *coeff) flush(z *big.Int) {
+	if c.inflated {
+		z.Mul(z, pow.BigTen(c.n))
+		z.Add(z, new(big.Int).SetUint64(c.acc))
+	} else {
+		z.SetUint64(c.acc)
+		c.inflated = true
+	}
+	c.acc, c.n = 0, 0
+}
 
-	return Invalid
+// finish flushes any remaining digits and returns the compact coefficient
+// and whether the coefficient is instead stored in z.
+func (c *coeff) finish(z *big.Int) (uint64, bool) {
+	if !c.inflated {
+		return c.acc, false
+	}
+	if c.n > 0 {
+		c.flush(z)
+	}
+	return 0, true
 }
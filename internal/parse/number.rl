@@ -1,6 +1,12 @@
 package parse
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ericlagergren/decimal/internal/arith/pow"
+)
 
 type Special uint8
 
@@ -10,6 +16,7 @@ const (
 	SNaN 
 	PInf
 	NInf
+	Finite
 )
 
 func (s Special) String() string {
@@ -19,35 +26,134 @@ func (s Special) String() string {
 	case SNaN:    return "sNaN"
 	case PInf:    return "+inf"
 	case NInf:    return "-inf"
+	case Finite:  return "finite"
 	default:      panic(fmt.Sprintf("unknown(%d)", s))
 	}
 }
 
-func ParseSpecial(data []byte) Special {
+// Number is a numeric string parsed by ParseNumber.
+type Number struct {
+	// Form is Finite, QNaN, SNaN, PInf, or NInf, or Invalid if the string is
+	// not a numeric string.
+	Form Special
+
+	// Neg is true if the string has a leading '-'.
+	Neg bool
+
+	// Compact is the coefficient if Inflated is false. Otherwise, the
+	// coefficient was stored in the *big.Int passed to ParseNumber.
+	Compact  uint64
+	Inflated bool
+
+	// Scale is the number of digits following the radix less the exponent.
+	// It's not bounds checked.
+	Scale int64
+
+	// Off is the offset of the first byte that does not match the grammar if
+	// Form is Invalid, or len(data) if data ends too early.
+	Off int
+}
+
+// maxExp bounds the exponent while it's being parsed. Larger exponents are
+// always out of range, so the remaining digits are ignored.
+const maxExp = math.MaxInt64 / 100
+
+// ParseNumber parses data, which must match the numeric string grammar from
+// the General Decimal Arithmetic Specification, in a single pass. Infinity and
+// NaN are case-insensitive and digits following a NaN are ignored. If the
+// coefficient does not fit into a uint64 it's stored in z.
+func ParseNumber(data string, z *big.Int) (n Number) {
 	cs, p, pe := 0, 0, len(data)
 
+	var (
+		c    coeff
+		exp  int64
+		eneg bool
+	)
+
 	%%{
-		machine parser;
-		
-		sign           = [-+];
-		indicator      = [eE];
-		decimal_part   = digit+ '.' digit* | '.'? digit+;
-		exponent_part  = indicator sign? digit+;
-		infinity       = 'inf'i 'inity'i?;
-		nan            = [sSqQ]? 'nan'i digit*;
-		numeric_value  = decimal_part exponent_part? | infinity;
-		numeric_string = sign? numeric_value | sign? nan;
-
-		main := (
-			  '+'?  infinity @{ return PInf }
-			| '-'   infinity @{ return NInf }
-			| 'q'i? nan      @{ return QNaN }
-			| 's'i  nan      @{ return SNaN }
-		);
+		machine number;
+
+		action neg       { n.Neg = true }
+		action digit     { c.digit(z, data[p]) }
+		action frac      { n.Scale++ }
+		action exp_neg   { eneg = true }
+		action exp_digit { if exp < maxExp { exp = exp*10 + int64(data[p]-'0') } }
+		action inf       { n.Form = PInf }
+		action qnan      { n.Form = QNaN }
+		action snan      { n.Form = SNaN }
+
+		coefficient    = (digit @digit)+;
+		fraction       = (digit @digit @frac)+;
+		decimal_part   = coefficient ('.' fraction?)? | '.' fraction;
+		exponent_part  = [eE] ('+' | '-' @exp_neg)? (digit @exp_digit)+;
+		infinity       = 'inf'i @inf 'inity'i?;
+		nan            = 'q'i? 'nan'i @qnan digit* | 's'i 'nan'i @snan digit*;
+
+		main := ('+' | '-' @neg)? (decimal_part exponent_part? | infinity | nan);
 
 		write data;
 		write init;
 		write exec;
 	}%%
-	return Invalid
+
+	if cs < number_first_final {
+		return Number{Off: p}
+	}
+	switch n.Form {
+	case Invalid:
+		n.Form = Finite
+	case PInf:
+		if n.Neg {
+			n.Form = NInf
+		}
+		return n
+	default:
+		return n
+	}
+	if eneg {
+		exp = -exp
+	}
+	n.Scale -= exp
+	n.Compact, n.Inflated = c.finish(z)
+	return n
+}
+
+// coeff accumulates a coefficient 19 digits, the most that always fit into a
+// uint64, at a time.
+type coeff struct {
+	acc      uint64
+	n        int64
+	inflated bool
+}
+
+func (c *coeff) digit(z *big.Int, b byte) {
+	c.acc = c.acc*10 + uint64(b-'0')
+	if c.n++; c.n == 19 {
+		c.flush(z)
+	}
+}
+
+// flush sets z = z * 10^c.n + c.acc or, if c is not yet inflated, z = c.acc.
+func (c *coeff) flush(z *big.Int) {
+	if c.inflated {
+		z.Mul(z, pow.BigTen(c.n))
+		z.Add(z, new(big.Int).SetUint64(c.acc))
+	} else {
+		z.SetUint64(c.acc)
+		c.inflated = true
+	}
+	c.acc, c.n = 0, 0
+}
+
+// finish flushes any remaining digits and returns the compact coefficient
+// and whether the coefficient is instead stored in z.
+func (c *coeff) finish(z *big.Int) (uint64, bool) {
+	if !c.inflated {
+		return c.acc, false
+	}
+	if c.n > 0 {
+		c.flush(z)
+	}
+	return 0, true
 }
@@ -1,56 +1,58 @@
 package parse
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+)
 
-func TestParseSpecial(t *testing.T) {
+func TestParseNumber(t *testing.T) {
 	for i, test := range [...]struct {
 		data string
-		s    Special
+		n    Number
+		big  string
 	}{
-		{"inf", PInf}, {"-infinity", NInf}, {"-iNfInItY", NInf}, {"+INF", PInf},
-		{"nan", QNaN}, {"qNaN", QNaN}, {"SNAN", SNaN}, {"na", Invalid}, {"nan123", QNaN},
-		{"snan3123123", SNaN}, {"fnan213123213", Invalid},
+		{"0", Number{Form: Finite}, ""},
+		{"-0.00", Number{Form: Finite, Neg: true, Scale: 2}, ""},
+		{"+12.345", Number{Form: Finite, Compact: 12345, Scale: 3}, ""},
+		{"1.", Number{Form: Finite, Compact: 1}, ""},
+		{".5", Number{Form: Finite, Compact: 5, Scale: 1}, ""},
+		{"1e+10", Number{Form: Finite, Compact: 1, Scale: -10}, ""},
+		{"-1.5E-3", Number{Form: Finite, Neg: true, Compact: 15, Scale: 4}, ""},
+		{"999999999999999999", Number{Form: Finite, Compact: 999999999999999999}, ""},
+		{"9999999999999999999", Number{Form: Finite, Inflated: true}, "9999999999999999999"},
+		{"12345678901234567890123456789.0123456789", Number{Form: Finite, Inflated: true, Scale: 10},
+			"123456789012345678901234567890123456789"},
+		{"inf", Number{Form: PInf}, ""},
+		{"-Infinity", Number{Form: NInf, Neg: true}, ""},
+		{"+nan", Number{Form: QNaN}, ""},
+		{"qNaN12", Number{Form: QNaN}, ""},
+		{"sNaN", Number{Form: SNaN}, ""},
+		{"", Number{Off: 0}, ""},
+		{"-", Number{Off: 1}, ""},
+		{".", Number{Off: 1}, ""},
+		{"1..2", Number{Off: 2}, ""},
+		{"1e", Number{Off: 2}, ""},
+		{"1e+", Number{Off: 3}, ""},
+		{"1f", Number{Off: 1}, ""},
+		{"infin", Number{Off: 5}, ""},
+		{"infinityy", Number{Off: 8}, ""},
+		{"nan1a", Number{Off: 4}, ""},
+		{"--1", Number{Off: 1}, ""},
 	} {
-		o := ParseSpecial(test.data)
-		if o != test.s {
-			t.Fatalf("#%d: wanted %q, got %q", i, test.s, o)
+		var z big.Int
+		n := ParseNumber(test.data, &z)
+		if n != test.n {
+			t.Fatalf("#%d: %q: wanted %+v, got %+v", i, test.data, test.n, n)
+		}
+		if test.big != "" && z.String() != test.big {
+			t.Fatalf("#%d: %q: wanted %s, got %s", i, test.data, test.big, &z)
 		}
 	}
 }
 
-var (
-	S          Special
-	benchmarks = [...]string{
-		"NaN", "foobar", "infinity", "Infinity", "snan", "+Inf", "-iNfInItY",
-		"SNAN", "QNAN", "+inf", "+baaaz", "infinitY", "zzycczz", "not-a-number",
-		"-INF", "sNAN", "Snan", "fnI+", "inFINity", "", "nan123123", "qnan231234",
-	}
-)
-
-func BenchmarkParseSpecial(b *testing.B) {
-	var ls Special
-	for i := 0; i < b.N; i++ {
-		ls = ParseSpecial(benchmarks[i%len(benchmarks)])
-	}
-	S = ls
-}
-
-func BenchmarkManual(b *testing.B) {
-	var ls Special
+func BenchmarkParseNumber(b *testing.B) {
+	var z big.Int
 	for i := 0; i < b.N; i++ {
-		switch benchmarks[i%len(benchmarks)] {
-		default:
-			ls = Invalid
-		case "NaN", "qNaN", "nan", "qnan":
-			ls = QNaN
-		case "sNaN", "snan":
-			ls = SNaN
-		case "Inf", "+Inf", "Infinity", "+Infinity",
-			"+inf", "+infinity", "inf":
-			ls = PInf
-		case "-Inf", "-Infinity", "-inf", "-infinity":
-			ls = NInf
-		}
+		ParseNumber("-12345.6789e-10", &z)
 	}
-	S = ls
 }
@@ -0,0 +1,108 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONFormat determines how MarshalJSON encodes a Big.
+type JSONFormat uint8
+
+const (
+	// JSONString encodes a Big as a JSON string, like "1.23". Consumers that
+	// decode JSON numbers into float64s won't silently lose precision.
+	JSONString JSONFormat = iota
+
+	// JSONNumber encodes a finite Big as a bare JSON number, like 1.23. JSON
+	// has no literals for NaN and infinity, so they're encoded as strings
+	// using the same form as MarshalText, like "NaN" or "+Inf".
+	JSONNumber
+)
+
+// MarshalJSON implements json.Marshaler. It encodes x according to x's
+// Context.JSONFormat. A nil x is encoded as null.
+func (x *Big) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+	text, err := x.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	if x.Context.JSONFormat == JSONNumber && x.form&(nan|inf) == 0 {
+		return text, nil
+	}
+	b := make([]byte, 0, len(text)+2)
+	b = append(b, '"')
+	b = append(b, text...)
+	return append(b, '"'), nil
+}
+
+var _ json.Marshaler = (*Big)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts both JSON numbers and
+// JSON strings containing any number accepted by SetString, regardless of
+// z's Context.JSONFormat. Like encoding/json, it treats null as a no-op.
+func (z *Big) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	switch {
+	case s == "null":
+		return nil
+	case len(s) > 0 && s[0] == '"':
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("Big.UnmarshalJSON: %v", err)
+		}
+	case !isJSONNumber(s):
+		return errors.New("Big.UnmarshalJSON: invalid JSON number")
+	}
+	if _, ok := z.SetString(s); !ok {
+		return errors.New("Big.UnmarshalJSON: invalid decimal format")
+	}
+	return nil
+}
+
+var _ json.Unmarshaler = (*Big)(nil)
+
+// isJSONNumber reports whether s is a valid JSON number. Unlike SetString,
+// bare words like "Inf" and "NaN" are not JSON numbers.
+func isJSONNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && '1' <= s[i] && s[i] <= '9':
+		i = skipDigits(s, i)
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		j := skipDigits(s, i+1)
+		if j == i+1 {
+			return false
+		}
+		i = j
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		j := skipDigits(s, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+	return i == len(s)
+}
+
+func skipDigits(s string, i int) int {
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	return i
+}
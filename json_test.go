@@ -0,0 +1,106 @@
+package decimal
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestBig_MarshalJSON(t *testing.T) {
+	type T struct {
+		X *Big `json:"x"`
+	}
+	for i, test := range [...]struct {
+		in     string
+		format JSONFormat
+		out    string
+	}{
+		0: {"1.23", JSONString, `{"x":"1.23"}`},
+		1: {"1.23", JSONNumber, `{"x":1.23}`},
+		2: {"-0.000", JSONNumber, `{"x":-0}`},
+		3: {"1E+100", JSONNumber, `{"x":1e+100}`},
+		4: {"123456789012345678901234567890", JSONNumber, `{"x":123456789012345678901234567890}`},
+		5: {"Inf", JSONNumber, `{"x":"+Inf"}`},
+		6: {"-Inf", JSONString, `{"x":"-Inf"}`},
+	} {
+		x := newbig(t, test.in)
+		x.Context.JSONFormat = test.format
+		b, err := json.Marshal(T{X: x})
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if string(b) != test.out {
+			t.Fatalf("#%d: wanted %s, got %s", i, test.out, b)
+		}
+	}
+
+	// Concurrent encodings with different formats don't race.
+	var wg sync.WaitGroup
+	for _, f := range [...]JSONFormat{JSONString, JSONNumber} {
+		wg.Add(1)
+		go func(f JSONFormat) {
+			defer wg.Done()
+			x := New(5, 1)
+			x.Context.JSONFormat = f
+			want := `"0.5"`
+			if f == JSONNumber {
+				want = `0.5`
+			}
+			for i := 0; i < 100; i++ {
+				if b, err := json.Marshal(x); err != nil || string(b) != want {
+					t.Errorf("wanted %s, got %s (%v)", want, b, err)
+					return
+				}
+			}
+		}(f)
+	}
+	wg.Wait()
+
+	b, err := json.Marshal(T{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"x":null}` {
+		t.Fatalf(`wanted {"x":null}, got %s`, b)
+	}
+}
+
+func TestBig_UnmarshalJSON(t *testing.T) {
+	for i, test := range [...]struct {
+		in  string
+		out string
+		ok  bool
+	}{
+		0:  {`1.23`, "1.23", true},
+		1:  {`"1.23"`, "1.23", true},
+		2:  {`-1e-5`, "-0.00001", true},
+		3:  {`"1.5"`, "1.5", true},
+		4:  {`"Infinity"`, "+Inf", true},
+		5:  {`12345678901234567890`, "12345678901234567890", true},
+		6:  {`Infinity`, "", false},
+		7:  {`"1.2.3"`, "", false},
+		8:  {`01`, "", false},
+		9:  {`1.`, "", false},
+		10: {`.5`, "", false},
+		11: {`1e`, "", false},
+	} {
+		z := new(Big)
+		z.Context.SetPrecision(0)
+		err := z.UnmarshalJSON([]byte(test.in))
+		if (err == nil) != test.ok {
+			t.Fatalf("#%d: %s: wanted ok == %t, got %v", i, test.in, test.ok, err)
+		}
+		if !test.ok {
+			continue
+		}
+		if want := newbig(t, test.out); (want.IsInf(0) && !z.IsInf(want.Sign())) ||
+			(!want.IsInf(0) && z.Cmp(want) != 0) {
+			t.Fatalf("#%d: wanted %s, got %s", i, want, z)
+		}
+	}
+
+	x := New(42, 0)
+	if err := json.Unmarshal([]byte(`null`), x); err != nil || x.Cmp(New(42, 0)) != 0 {
+		t.Fatalf("null: wanted 42, got %s (%v)", x, err)
+	}
+}
@@ -1,27 +1,302 @@
 package math
 
-import "github.com/ericlagergren/decimal"
+import (
+	"math"
 
-// TODO: generic taylor series function?
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+)
 
-// expTaylor sets z to exp(x).
+// SeriesGenerator represents an infinite series
+//
+//     s = a0 + a1 + a2 + a3 + ...
+//
+type SeriesGenerator interface {
+	// Next returns the next term in the series. For efficiency's sake, the
+	// caller must not modify the returned value.
+	Next() *decimal.Big
+}
+
+// Acceleration is a method used to accelerate the convergence of a series.
+type Acceleration uint8
+
+// The following acceleration methods are supported.
+const (
+	// NoAcceleration sums the terms directly.
+	NoAcceleration Acceleration = iota
+
+	// Euler applies the Euler transform to the terms of the series. It
+	// should only be used with alternating series, where it's often
+	// dramatically faster than summing the terms directly.
+	Euler
+
+	// Shanks applies the Shanks transformation to the partial sums of the
+	// series using Wynn's ε algorithm. It works well for alternating series
+	// and series whose terms decrease geometrically.
+	Shanks
+
+	// Richardson applies Richardson extrapolation to the partial sums of the
+	// series. It works well for series whose partial sums have an error that
+	// is a polynomial in 1/n, like Σ 1/n².
+	Richardson
+)
+
+// Accelerator, if implemented, will allow SeriesGenerators to choose the
+// Acceleration used by Sum.
+type Accelerator interface {
+	Acceleration() Acceleration
+}
+
+// Sum sets z to the sum of the series provided by the SeriesGenerator to prec
+// precision and returns z.
+//
+// If the SeriesGenerator does not implement the Accelerator interface, Sum
+// stops once a term is too small to affect the sum at prec precision.
+// Otherwise, Sum stops once two consecutive estimates of the sum agree to
+// prec precision. Because Sum only examines the most recent term or estimate,
+// the SeriesGenerator should not produce interleaved zero terms; for example,
+// the series for sin(x) should skip the terms for even powers of x.
+//
+// Sum will panic after 1<<63 - 1 terms.
+func Sum(z *decimal.Big, g SeriesGenerator, prec int32) *decimal.Big {
+	method := NoAcceleration
+	if a, ok := g.(Accelerator); ok {
+		method = a.Acceleration()
+	}
+
+	var s summer
+	switch method {
+	case NoAcceleration:
+		s = &directSum{}
+	case Euler:
+		s = &eulerSum{}
+	case Shanks:
+		s = &shanksSum{}
+	case Richardson:
+		s = &richardsonSum{}
+	default:
+		panic("Sum: unknown Acceleration")
+	}
+
+	for i := int64(0); i < math.MaxInt64; i++ {
+		if sum, done := s.add(g.Next(), i, prec); done {
+			ctx := z.Context
+			z.Copy(sum)
+			z.Context = ctx
+			return z.Round(prec)
+		}
+	}
+	panic("Sum: too many iterations")
+}
+
+// summer incrementally computes the sum of a series.
+type summer interface {
+	// add adds the nth term, t, to the series. If the sum has converged to
+	// prec precision, add returns the sum and true.
+	add(t *decimal.Big, n int64, prec int32) (sum *decimal.Big, done bool)
+}
+
+// estimate tracks successive estimates of the sum of an accelerated series.
+type estimate struct {
+	prev *decimal.Big
+}
+
+// next records est as the newest estimate of the sum and returns true if it
+// agrees with the previous estimate to prec precision.
+func (e *estimate) next(est *decimal.Big, prec int32) bool {
+	if e.prev == nil {
+		e.prev = calc.Work(prec + calc.GuardDigits).Set(est)
+		return false
+	}
+	d := calc.Work(prec + calc.GuardDigits)
+	if calc.Negligible(d.Sub(est, e.prev), est, prec) {
+		return true
+	}
+	e.prev.Set(est)
+	return false
+}
+
+// directSum sums the terms directly.
+type directSum struct {
+	sum *decimal.Big
+}
+
+func (s *directSum) add(t *decimal.Big, n int64, prec int32) (*decimal.Big, bool) {
+	if s.sum == nil {
+		s.sum = calc.Work(prec + calc.GuardDigits)
+	}
+	if n > 0 && calc.Negligible(t, s.sum, prec) {
+		return s.sum, true
+	}
+	s.sum.Add(s.sum, t)
+	return s.sum, false
+}
+
+// eulerSum applies the Euler transform using the algorithm from "Numerical
+// Recipes in C: The Art of Scientific Computing" (ISBN 0-521-43105-5), pg
+// 167, which incorporates the terms one at a time.
+type eulerSum struct {
+	sum  *decimal.Big
+	wksp []*decimal.Big
+	tmp  *decimal.Big
+	dum  *decimal.Big
+	nt   int // nterm
+	est  estimate
+}
+
+func (s *eulerSum) add(t *decimal.Big, n int64, prec int32) (*decimal.Big, bool) {
+	wp := prec + calc.GuardDigits
+	if n == 0 {
+		s.sum = calc.Work(wp)
+		s.tmp = calc.Work(wp)
+		s.dum = calc.Work(wp)
+		s.wksp = append(s.wksp, calc.Work(wp).Set(t))
+		s.nt = 1
+		// sum = 0.5 * wksp[0]
+		s.sum.Mul(ptFive, t)
+		return s.sum, s.est.next(s.sum, prec)
+	}
+
+	s.tmp.Set(s.wksp[0])
+	s.wksp[0].Set(t)
+	for j := 0; j < s.nt-1; j++ {
+		s.dum.Set(s.wksp[j+1])
+		// wksp[j+1] = 0.5 * (wksp[j] + tmp)
+		s.wksp[j+1].Mul(ptFive, s.wksp[j+1].Add(s.wksp[j], s.tmp))
+		s.tmp.Set(s.dum)
+	}
+	if len(s.wksp) == s.nt {
+		s.wksp = append(s.wksp, calc.Work(wp))
+	}
+	next := s.wksp[s.nt]
+	next.Mul(ptFive, next.Add(s.wksp[s.nt-1], s.tmp))
+
+	// If the newest difference is no larger than the previous one, increase
+	// the order of the transform. Otherwise, keep the current order.
+	if s.tmp.Abs(next).Cmp(s.dum.Abs(s.wksp[s.nt-1])) <= 0 {
+		s.nt++
+		s.sum.Add(s.sum, s.tmp.Mul(ptFive, next))
+	} else {
+		s.sum.Add(s.sum, next)
+	}
+	return s.sum, s.est.next(s.sum, prec)
+}
+
+// shanksSum applies the Shanks transformation to the partial sums of the
+// series using Wynn's ε algorithm. The algorithm is adapted from E. J.
+// Weniger. 1989. Nonlinear sequence transformations for the acceleration of
+// convergence and the summation of divergent series. Computer Physics Reports
+// 10, 5-6 (December 1989), 189-371.
+type shanksSum struct {
+	sum *decimal.Big
+	e   []*decimal.Big // the most recent counterdiagonal of the ε table
+	aux [3]*decimal.Big
+	tmp *decimal.Big
+	est estimate
+}
+
+func (s *shanksSum) add(t *decimal.Big, n int64, prec int32) (*decimal.Big, bool) {
+	wp := prec + calc.GuardDigits
+	if n == 0 {
+		s.sum = calc.Work(wp)
+		s.tmp = calc.Work(wp)
+		for i := range s.aux {
+			s.aux[i] = calc.Work(wp)
+		}
+	}
+	s.sum.Add(s.sum, t)
+	s.e = append(s.e, calc.Work(wp))
+
+	aux1, aux2, aux3 := s.aux[0], s.aux[1], s.aux[2]
+	aux2.SetMantScale(0, 0)
+	aux1.Set(s.e[0])
+	s.e[0].Set(s.sum)
+	for j := 1; j < len(s.e); j++ {
+		aux3.Set(aux2)
+		aux2.Set(aux1)
+		if j < len(s.e)-1 {
+			aux1.Set(s.e[j])
+		}
+		s.tmp.Sub(s.e[j-1], aux2)
+		if s.tmp.Sign() == 0 {
+			// Two consecutive elements are equal, so the sequence has
+			// converged. Further elements would divide by zero.
+			s.e = s.e[:j]
+			return s.e[(j-1)&^1], true
+		}
+		s.e[j].Add(aux3, s.tmp.Quo(one, s.tmp))
+	}
+	// Only the even columns of the ε table are estimates of the sum.
+	est := s.e[(len(s.e)-1)&^1]
+	return est, s.est.next(est, prec)
+}
+
+// richardsonSum applies Richardson extrapolation to the partial sums of the
+// series, assuming the error of the nth partial sum is a polynomial in 1/n.
+// To keep the extrapolation numerically stable, it only uses the partial sums
+// of 1, 2, 4, 8, ... terms, in the same manner as Romberg integration.
+type richardsonSum struct {
+	sum  *decimal.Big
+	row  []*decimal.Big // most recent row of the tableau
+	next int64          // number of terms in the next partial sum to use
+	tmp  *decimal.Big
+	prev *decimal.Big
+	est  estimate
+}
+
+func (s *richardsonSum) add(t *decimal.Big, n int64, prec int32) (*decimal.Big, bool) {
+	wp := prec + calc.GuardDigits
+	if n == 0 {
+		s.sum = calc.Work(wp)
+		s.tmp = calc.Work(wp)
+		s.prev = calc.Work(wp)
+		s.next = 1
+	}
+	s.sum.Add(s.sum, t)
+	if n+1 != s.next {
+		return s.sum, false
+	}
+	s.next *= 2
+
+	s.row = append(s.row, calc.Work(wp))
+	s.prev.Set(s.row[0])
+	s.row[0].Set(s.sum)
+	for j := 1; j < len(s.row); j++ {
+		// T[i][j] = T[i][j-1] + (T[i][j-1] - T[i-1][j-1]) / (2^j - 1)
+		s.tmp.Sub(s.row[j-1], s.prev)
+		s.tmp.Quo(s.tmp, decimal.New(1<<uint(j)-1, 0))
+		s.prev.Set(s.row[j])
+		s.row[j].Add(s.row[j-1], s.tmp)
+	}
+	est := s.row[len(s.row)-1]
+	return est, s.est.next(est, prec)
+}
+
+// expSeries is a SeriesGenerator that computes exp(x) using its Taylor
+// series,
+//
+//     exp(x) = 1 + x + x²/2! + x³/3! + ...
+//
+type expSeries struct {
+	x    *decimal.Big
+	term *decimal.Big
+	n    int64
+}
+
+func (e *expSeries) Next() *decimal.Big {
+	if e.n == 0 {
+		e.term.SetMantScale(1, 0)
+	} else {
+		e.term.Mul(e.term, e.x)
+		e.term.Quo(e.term, decimal.New(e.n, 0))
+	}
+	e.n++
+	return e.term
+}
+
+// expTaylor sets z to exp(x) and returns z.
 func expTaylor(z, x *decimal.Big) *decimal.Big {
-	return nil
-}
-
-func expTaylorCompact(z *decimal.Big, x int64) *decimal.Big {
-	var (
-		sum  = int64(1)
-		pow  = x
-		fac  = int64(1)
-		prev int64
-	)
-	for i := int64(2); sum != prev; i++ {
-		pow *= x
-		fac *= i
-		term := pow / fac
-		prev = sum
-		sum += term
-	}
-	return nil
+	prec := z.Context.Precision()
+	g := expSeries{x: x, term: calc.Work(prec + calc.GuardDigits)}
+	return Sum(z, &g, prec)
 }
@@ -0,0 +1,122 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// ln2Series is the alternating series
+//
+//     ln(2) = 1 - 1/2 + 1/3 - 1/4 + ...
+//
+type ln2Series struct {
+	term  decimal.Big
+	n     int64
+	accel Acceleration
+}
+
+func (l *ln2Series) Next() *decimal.Big {
+	l.n++
+	l.term.Context.SetPrecision(50)
+	l.term.Quo(one, decimal.New(l.n, 0))
+	if l.n%2 == 0 {
+		l.term.Neg(&l.term)
+	}
+	return &l.term
+}
+
+func (l *ln2Series) Acceleration() Acceleration { return l.accel }
+
+// zeta2Series is the series
+//
+//     π²/6 = 1 + 1/4 + 1/9 + 1/16 + ...
+//
+type zeta2Series struct {
+	term decimal.Big
+	n    int64
+}
+
+func (z *zeta2Series) Next() *decimal.Big {
+	z.n++
+	z.term.Context.SetPrecision(50)
+	return z.term.Quo(one, decimal.New(z.n*z.n, 0))
+}
+
+func (z *zeta2Series) Acceleration() Acceleration { return Richardson }
+
+// geomSeries is the geometric series
+//
+//     2 = 1 + 1/2 + 1/4 + 1/8 + ...
+//
+type geomSeries struct {
+	term decimal.Big
+	n    int64
+}
+
+func (g *geomSeries) Next() *decimal.Big {
+	if g.n == 0 {
+		g.term.SetMantScale(1, 0)
+	} else {
+		g.term.Mul(&g.term, ptFive)
+	}
+	g.n++
+	return &g.term
+}
+
+func TestSum(t *testing.T) {
+	zeta2 := new(decimal.Big).Mul(Pi, Pi)
+	zeta2.Context.SetPrecision(50)
+	zeta2.Quo(zeta2, six)
+
+	for i, test := range [...]struct {
+		g    SeriesGenerator
+		prec int32
+		want *decimal.Big
+	}{
+		0: {&geomSeries{}, 20, two},
+		1: {&ln2Series{accel: Euler}, 20, Ln2},
+		2: {&ln2Series{accel: Shanks}, 20, Ln2},
+		3: {&zeta2Series{}, 12, zeta2},
+	} {
+		z := new(decimal.Big)
+		z.Context.SetPrecision(test.prec)
+		Sum(z, test.g, test.prec)
+
+		want := new(decimal.Big)
+		want.Context.SetPrecision(test.prec)
+		want.Set(test.want)
+		if z.Cmp(want) != 0 {
+			t.Fatalf(`#%d:
+wanted: %s
+got   : %s
+`, i, want, z)
+		}
+	}
+}
+
+func TestExpTaylor(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		prec int32
+		want string
+	}{
+		0: {"1", 30, E.String()},
+		1: {"0.5", 20, "1.6487212707001281468"},
+		2: {"-2", 15, "0.135335283236613"},
+	} {
+		z := new(decimal.Big)
+		z.Context.SetPrecision(test.prec)
+		expTaylor(z, newbig(test.x))
+
+		want := new(decimal.Big)
+		want.Context.SetPrecision(test.prec)
+		want.Set(newbig(test.want))
+		if z.Cmp(want) != 0 {
+			t.Fatalf(`#%d: exp(%s):
+wanted: %s
+got   : %s
+`, i, test.x, want, z)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package math
+
+import (
+	"errors"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+)
+
+// Log sets z to the natural logarithm of x and returns z.
+func Log(z, x *decimal.Big) *decimal.Big {
+	if snan := x.IsNaN(-1); snan || x.IsNaN(+1) {
+		z.SetNaN(snan)
+		return signal(z,
+			decimal.InvalidOperation, decimal.ErrNaN{Msg: "logarithm of NaN"})
+	}
+	if xs := x.Sign(); xs <= 0 {
+		if xs == 0 {
+			// ln 0 = -Inf
+			return z.SetInf(true)
+		}
+		z.SetNaN(false)
+		return signal(z,
+			decimal.InvalidOperation,
+			errors.New("math.Log: cannot take logarithm of negative number"),
+		)
+	}
+	if x.IsInf(+1) {
+		return z.SetInf(false)
+	}
+	if x.Cmp(one) == 0 {
+		// ln 1 = 0
+		return z.SetMantScale(0, 0)
+	}
+
+	// Reduce x to m ∈ [0.75, 1.5) such that
+	//
+	//     x = m * 2**j * 10**k
+	//     ln(x) = ln(m) + j*ln(2) + k*ln(10)
+	//
+	// unless x is already close to 1, in which case reducing it would only
+	// lose digits to cancellation.
+	var (
+		prec = z.Context.Precision()
+		wp   = prec + calc.GuardDigits
+		m    = calc.Work(wp).Set(x)
+		j    int64
+		k    int64
+	)
+	if m.Cmp(ptFive) < 0 || m.Cmp(two) >= 0 {
+		k = calc.Adjusted(m) + 1
+		m.SetScale(m.Scale() + int32(k)) // m ∈ [0.1, 1)
+		for m.Cmp(threeQuarters) < 0 {
+			m.Mul(m, two)
+			j--
+		}
+	}
+
+	// ln(m) = 2 * atanh((m - 1) / (m + 1))
+	u := calc.Work(wp)
+	u.Quo(calc.Work(wp).Sub(m, one), calc.Work(wp).Add(m, one))
+	g := atanhSeries{
+		u2:   calc.Work(wp).Mul(u, u),
+		pow:  u,
+		term: calc.Work(wp),
+	}
+	r := Sum(calc.Work(wp), &g, wp)
+	r.Mul(r, two)
+
+	tmp := calc.Work(wp)
+	if j != 0 {
+		r.Add(r, tmp.Mul(Ln2, decimal.New(j, 0)))
+	}
+	if k != 0 {
+		r.Add(r, tmp.Mul(Ln10, decimal.New(k, 0)))
+	}
+	return z.Set(r)
+}
+
+var threeQuarters = decimal.New(75, 2)
+
+// atanhSeries is a SeriesGenerator that computes atanh(u) using its Taylor
+// series,
+//
+//     atanh(u) = u + u³/3 + u⁵/5 + u⁷/7 + ...
+//
+type atanhSeries struct {
+	u2   *decimal.Big // u*u
+	pow  *decimal.Big // u ** (2n+1)
+	term *decimal.Big
+	n    int64
+}
+
+func (a *atanhSeries) Next() *decimal.Big {
+	if a.n > 0 {
+		a.pow.Mul(a.pow, a.u2)
+	}
+	a.term.Quo(a.pow, decimal.New(2*a.n+1, 0))
+	a.n++
+	return a.term
+}
@@ -0,0 +1,34 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestLog(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		prec int32
+		want string
+	}{
+		0: {"2", 30, "0.693147180559945309417232121458"},
+		1: {"10", 30, "2.30258509299404568401799145468"},
+		2: {"0.5", 30, "-0.693147180559945309417232121458"},
+		3: {"1.0001", 30, "0.0000999950003333083353331666809511"},
+		4: {"123456.789", 30, "11.7236464871858809811399589839"},
+		5: {"0.000123", 30, "-9.00332620259185660884594011815"},
+		6: {"0.9", 16, "-0.1053605156578263"},
+		7: {"1", 16, "0"},
+	} {
+		z := new(decimal.Big)
+		z.Context.SetPrecision(test.prec)
+		Log(z, newbig(test.x))
+		if want := newbig(test.want); z.Cmp(want) != 0 {
+			t.Fatalf(`#%d: ln(%s):
+wanted: %s
+got   : %s
+`, i, test.x, want, z)
+		}
+	}
+}
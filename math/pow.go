@@ -0,0 +1,86 @@
+package math
+
+import (
+	"errors"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/arith"
+	"github.com/ericlagergren/decimal/internal/calc"
+)
+
+// Pow sets z to x ** y and returns z. The result is undefined if x or y is an
+// infinity.
+func Pow(z, x, y *decimal.Big) *decimal.Big {
+	if x.IsNaN(0) || y.IsNaN(0) {
+		z.SetNaN(false)
+		return signal(z,
+			decimal.InvalidOperation, decimal.ErrNaN{Msg: "power of NaN"})
+	}
+	if y.Sign() == 0 {
+		// x ** 0 = 1
+		return z.SetMantScale(1, 0)
+	}
+	if x.Sign() == 0 {
+		if y.Sign() < 0 {
+			// 0 ** -y = +Inf
+			return z.SetInf(false)
+		}
+		// 0 ** y = 0
+		return z.SetMantScale(0, 0)
+	}
+
+	prec := z.Context.Precision()
+	if y.IsInt() && !y.IsBig() {
+		return powInt(z, x, y.Int64(), prec)
+	}
+	if x.Sign() < 0 {
+		z.SetNaN(false)
+		return signal(z,
+			decimal.InvalidOperation,
+			errors.New("math.Pow: negative number raised to a non-integer power"),
+		)
+	}
+
+	// x ** y = exp(y * ln(x))
+	//
+	// exp magnifies the relative error in its argument by the argument's
+	// magnitude, so compute the logarithm with enough extra digits to cover
+	// it.
+	t := calc.Work(prec + calc.GuardDigits)
+	Log(t, x)
+	t.Mul(t, y)
+	if e := calc.Adjusted(t); e > 0 {
+		t.Context.SetPrecision(prec + calc.GuardDigits + int32(e))
+		Log(t, x)
+		t.Mul(t, y)
+	}
+	return z.Set(Exp(calc.Work(prec+calc.GuardDigits), t))
+}
+
+// powInt sets z to x ** n rounded to prec digits and returns z.
+func powInt(z, x *decimal.Big, n int64, prec int32) *decimal.Big {
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	// Each multiplication can introduce an error, so use extra digits for the
+	// intermediate results.
+	wp := prec + calc.GuardDigits + int32(arith.Length(n))
+	var (
+		r = calc.Work(wp).SetMantScale(1, 0)
+		b = calc.Work(wp).Set(x)
+	)
+	for n > 0 {
+		if n&1 != 0 {
+			r.Mul(r, b)
+		}
+		if n >>= 1; n > 0 {
+			b.Mul(b, b)
+		}
+	}
+	if neg {
+		r.Quo(one, r)
+	}
+	return z.Set(r)
+}
@@ -0,0 +1,34 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestPow(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y string
+		prec int32
+		want string
+	}{
+		0: {"2", "0.5", 30, "1.41421356237309504880168872421"},
+		1: {"1.05", "2.5", 30, "1.12972632194704572175011951453"},
+		2: {"10", "-1.5", 30, "0.0316227766016837933199889354443"},
+		3: {"3", "7", 30, "2187"},
+		4: {"1.1", "-3", 30, "0.751314800901577761081893313298"},
+		5: {"0.5", "10.25", 30, "0.000821187905521205608428833472884"},
+		6: {"5", "0", 16, "1"},
+		7: {"0", "3", 16, "0"},
+	} {
+		z := new(decimal.Big)
+		z.Context.SetPrecision(test.prec)
+		Pow(z, newbig(test.x), newbig(test.y))
+		if want := newbig(test.want); z.Cmp(want) != 0 {
+			t.Fatalf(`#%d: %s ** %s:
+wanted: %s
+got   : %s
+`, i, test.x, test.y, want, z)
+		}
+	}
+}
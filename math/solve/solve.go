@@ -0,0 +1,327 @@
+// Package solve implements root-finding algorithms for functions over
+// decimals.
+package solve
+
+import (
+	"errors"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+)
+
+// Func is a function over decimals. It sets z to f(x) and returns z.
+//
+// z's Context is the Context the solver uses for intermediate calculations;
+// Funcs should use it for any temporary decimals they require.
+type Func func(z, x *decimal.Big) *decimal.Big
+
+var (
+	// ErrMaxIterations is returned when a solver does not converge within the
+	// allowed number of iterations.
+	ErrMaxIterations = errors.New("solve: maximum number of iterations exceeded")
+
+	// ErrNotBracketed is returned when the interval given to Bisect or Brent
+	// does not bracket a root. That is, f(a) and f(b) have the same sign.
+	ErrNotBracketed = errors.New("solve: root is not bracketed")
+
+	// ErrZeroDerivative is returned by Newton when the derivative is zero.
+	ErrZeroDerivative = errors.New("solve: derivative is zero")
+
+	// ErrNotFinite is returned when a Func returns an infinity or a NaN
+	// value.
+	ErrNotFinite = errors.New("solve: function value is not finite")
+)
+
+// Newton sets z to a root of f to prec precision using Newton's method,
+// starting at x0. df must be the derivative of f. Newton converges
+// quadratically if x0 is close enough to a root, but might not converge at
+// all otherwise; for a guaranteed (but slower) result use Brent.
+//
+// Newton stops once an iteration changes the estimate by less than one unit
+// in the last place at prec precision or f(x) = 0. If that doesn't happen
+// within maxIter iterations, Newton returns ErrMaxIterations.
+func Newton(z *decimal.Big, f, df Func, x0 *decimal.Big, prec int32, maxIter int) (*decimal.Big, error) {
+	var (
+		x  = newWork(z, prec).Set(x0)
+		fx = newWork(z, prec)
+		dx = newWork(z, prec)
+	)
+	for i := 0; i < maxIter; i++ {
+		if err := eval(f, fx, x); err != nil {
+			return z, err
+		}
+		if fx.Sign() == 0 {
+			return set(z, x, prec), nil
+		}
+		if err := eval(df, dx, x); err != nil {
+			return z, err
+		}
+		if dx.Sign() == 0 {
+			return z, ErrZeroDerivative
+		}
+
+		// x := x - f(x)/f'(x)
+		dx.Quo(fx, dx)
+		x.Sub(x, dx)
+		if calc.Negligible(dx, x, prec) {
+			return set(z, x, prec), nil
+		}
+	}
+	return z, ErrMaxIterations
+}
+
+// Bisect sets z to a root of f to prec precision using the bisection method.
+// The root must be bracketed by a and b. That is, f(a) and f(b) must have
+// opposite signs. Otherwise, Bisect returns ErrNotBracketed.
+//
+// Bisect stops once the interval is smaller than one unit in the last place
+// at prec precision or f(x) = 0. If that doesn't happen within maxIter
+// iterations, Bisect returns ErrMaxIterations.
+func Bisect(z *decimal.Big, f Func, a, b *decimal.Big, prec int32, maxIter int) (*decimal.Big, error) {
+	var (
+		lo  = newWork(z, prec).Set(a)
+		hi  = newWork(z, prec).Set(b)
+		mid = newWork(z, prec)
+		flo = newWork(z, prec)
+		fhi = newWork(z, prec)
+		fm  = newWork(z, prec)
+		w   = newWork(z, prec)
+	)
+	if err := eval(f, flo, lo); err != nil {
+		return z, err
+	}
+	if flo.Sign() == 0 {
+		return set(z, lo, prec), nil
+	}
+	if err := eval(f, fhi, hi); err != nil {
+		return z, err
+	}
+	if fhi.Sign() == 0 {
+		return set(z, hi, prec), nil
+	}
+	if flo.Sign() == fhi.Sign() {
+		return z, ErrNotBracketed
+	}
+
+	for i := 0; i < maxIter; i++ {
+		// mid := lo + (hi - lo)/2
+		w.Sub(hi, lo)
+		w.Mul(w, ptFive)
+		mid.Add(lo, w)
+		if calc.Negligible(w, mid, prec) {
+			return set(z, mid, prec), nil
+		}
+
+		if err := eval(f, fm, mid); err != nil {
+			return z, err
+		}
+		if fm.Sign() == 0 {
+			return set(z, mid, prec), nil
+		}
+		if fm.Sign() == flo.Sign() {
+			lo.Set(mid)
+			flo.Set(fm)
+		} else {
+			hi.Set(mid)
+		}
+	}
+	return z, ErrMaxIterations
+}
+
+// Brent sets z to a root of f to prec precision using Brent's method. The
+// root must be bracketed by a and b. That is, f(a) and f(b) must have
+// opposite signs. Otherwise, Brent returns ErrNotBracketed.
+//
+// Brent combines bisection, the secant method, and inverse quadratic
+// interpolation. It's guaranteed to converge as long as the root is
+// bracketed, and it's usually nearly as fast as Newton's method without
+// requiring a derivative.
+//
+// Brent stops once the interval is smaller than one unit in the last place
+// at prec precision or f(x) = 0. If that doesn't happen within maxIter
+// iterations, Brent returns ErrMaxIterations.
+func Brent(z *decimal.Big, f Func, a, b *decimal.Big, prec int32, maxIter int) (*decimal.Big, error) {
+	// Algorithm from "Numerical Recipes in C: The Art of Scientific
+	// Computing" (ISBN 0-521-43105-5), pg 361.
+	var (
+		a0  = newWork(z, prec).Set(a)
+		b0  = newWork(z, prec).Set(b)
+		c0  = newWork(z, prec)
+		d   = newWork(z, prec)
+		e   = newWork(z, prec)
+		fa  = newWork(z, prec)
+		fb  = newWork(z, prec)
+		fc  = newWork(z, prec)
+		m   = newWork(z, prec) // xm
+		tol = newWork(z, prec) // tol1
+		p   = newWork(z, prec)
+		q   = newWork(z, prec)
+		r   = newWork(z, prec)
+		s   = newWork(z, prec)
+		t0  = newWork(z, prec)
+		t1  = newWork(z, prec)
+	)
+	if err := eval(f, fa, a0); err != nil {
+		return z, err
+	}
+	if err := eval(f, fb, b0); err != nil {
+		return z, err
+	}
+	if fa.Sign() == 0 {
+		return set(z, a0, prec), nil
+	}
+	if fb.Sign() == 0 {
+		return set(z, b0, prec), nil
+	}
+	if fa.Sign() == fb.Sign() {
+		return z, ErrNotBracketed
+	}
+
+	c0.Set(b0)
+	fc.Set(fb)
+	for i := 0; i < maxIter; i++ {
+		if fb.Sign() == fc.Sign() {
+			// Rename a, b, c and adjust the bounding interval, d.
+			c0.Set(a0)
+			fc.Set(fa)
+			d.Sub(b0, a0)
+			e.Set(d)
+		}
+		if t0.Abs(fc).Cmp(t1.Abs(fb)) < 0 {
+			a0.Set(b0)
+			b0.Set(c0)
+			c0.Set(a0)
+			fa.Set(fb)
+			fb.Set(fc)
+			fc.Set(fa)
+		}
+
+		// Convergence check.
+		ulp(tol, b0, prec)
+		m.Sub(c0, b0)
+		m.Mul(m, ptFive)
+		if t0.Abs(m).Cmp(tol) <= 0 || fb.Sign() == 0 {
+			return set(z, b0, prec), nil
+		}
+
+		if t0.Abs(e).Cmp(tol) >= 0 && t1.Abs(fa).Cmp(r.Abs(fb)) > 0 {
+			// Attempt inverse quadratic interpolation.
+			s.Quo(fb, fa)
+			if a0.Cmp(c0) == 0 {
+				// p = 2 * xm * s
+				p.Mul(two, m)
+				p.Mul(p, s)
+				// q = 1 - s
+				q.Sub(one, s)
+			} else {
+				q.Quo(fa, fc)
+				r.Quo(fb, fc)
+				// p = s * (2 * xm * q * (q - r) - (b - a) * (r - 1))
+				t0.Sub(q, r)
+				t0.Mul(t0, q)
+				t0.Mul(t0, m)
+				t0.Mul(t0, two)
+				t1.Sub(b0, a0)
+				p.Sub(r, one)
+				t1.Mul(t1, p)
+				p.Sub(t0, t1)
+				p.Mul(p, s)
+				// q = (q - 1) * (r - 1) * (s - 1)
+				q.Sub(q, one)
+				q.Mul(q, t0.Sub(r, one))
+				q.Mul(q, t0.Sub(s, one))
+			}
+			// Check whether in bounds.
+			if p.Sign() > 0 {
+				q.Neg(q)
+			}
+			p.Abs(p)
+
+			// min1 = 3 * xm * q - |tol1 * q|
+			t0.Mul(three, m)
+			t0.Mul(t0, q)
+			t1.Mul(tol, q)
+			t0.Sub(t0, t1.Abs(t1))
+			// min2 = |e * q|
+			t1.Mul(e, q)
+			t1.Abs(t1)
+			if t0.Cmp(t1) > 0 {
+				t0.Set(t1)
+			}
+			// if 2 * p < min(min1, min2)
+			if t1.Mul(two, p).Cmp(t0) < 0 {
+				// Accept interpolation.
+				e.Set(d)
+				d.Quo(p, q)
+			} else {
+				// Interpolation failed, use bisection.
+				d.Set(m)
+				e.Set(d)
+			}
+		} else {
+			// Bounds decreasing too slowly, use bisection.
+			d.Set(m)
+			e.Set(d)
+		}
+
+		// Move last best guess to a.
+		a0.Set(b0)
+		fa.Set(fb)
+
+		// Evaluate new trial root.
+		if t0.Abs(d).Cmp(tol) > 0 {
+			b0.Add(b0, d)
+		} else if m.Sign() >= 0 {
+			b0.Add(b0, tol)
+		} else {
+			b0.Sub(b0, tol)
+		}
+		if err := eval(f, fb, b0); err != nil {
+			return z, err
+		}
+	}
+	return z, ErrMaxIterations
+}
+
+var (
+	one    = decimal.New(1, 0)
+	two    = decimal.New(2, 0)
+	three  = decimal.New(3, 0)
+	ptFive = decimal.New(5, 1)
+)
+
+// newWork returns a decimal with z's Context and prec + calc.GuardDigits
+// precision, suitable for intermediate calculations.
+func newWork(z *decimal.Big, prec int32) *decimal.Big {
+	x := new(decimal.Big)
+	x.Context = z.Context
+	x.Context.SetPrecision(prec + calc.GuardDigits)
+	return x
+}
+
+// eval sets z to f(x) and returns an error if the result is not finite.
+func eval(f Func, z, x *decimal.Big) error {
+	if f(z, x); z.IsInf(0) || z.IsNaN(0) {
+		return ErrNotFinite
+	}
+	return nil
+}
+
+// set sets z to x rounded to prec precision and returns z. z's Context is
+// not otherwise modified.
+func set(z, x *decimal.Big, prec int32) *decimal.Big {
+	ctx := z.Context
+	z.Copy(x)
+	z.Context = ctx
+	return z.Round(prec)
+}
+
+// ulp sets z to one unit in the last place of x at prec precision and returns
+// z. If x == 0, z is set to 10 ** -prec.
+func ulp(z, x *decimal.Big, prec int32) *decimal.Big {
+	e := -int64(prec)
+	if x.Sign() != 0 {
+		e += calc.Adjusted(x) + 1
+	}
+	return z.SetMantScale(1, int32(-e))
+}
@@ -0,0 +1,111 @@
+package solve
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func newbig(t *testing.T, s string) *decimal.Big {
+	x, ok := new(decimal.Big).SetString(s)
+	if !ok {
+		t.Fatalf("bad input: %q", s)
+	}
+	return x
+}
+
+// sqrt2 is f(x) = x² - 2.
+func sqrt2(z, x *decimal.Big) *decimal.Big {
+	return z.Sub(z.Mul(x, x), two)
+}
+
+// dsqrt2 is f'(x) = 2x.
+func dsqrt2(z, x *decimal.Big) *decimal.Big {
+	return z.Mul(two, x)
+}
+
+// cbrt10 is f(x) = x³ - 10.
+func cbrt10(z, x *decimal.Big) *decimal.Big {
+	z.Mul(x, x)
+	z.Mul(z, x)
+	return z.Sub(z, decimal.New(10, 0))
+}
+
+// npv is the net present value of the cash flows -100, 60, 60 at rate x.
+func npv(z, x *decimal.Big) *decimal.Big {
+	d := new(decimal.Big)
+	d.Context = z.Context
+	d.Add(one, x)
+
+	t := new(decimal.Big)
+	t.Context = z.Context
+	z.SetMantScale(-100, 0)
+	z.Add(z, t.Quo(decimal.New(60, 0), d))
+	return z.Add(z, t.Quo(decimal.New(60, 0), t.Mul(d, d)))
+}
+
+type solver func(z *decimal.Big, f Func, a, b *decimal.Big, prec int32) (*decimal.Big, error)
+
+var solvers = [...]struct {
+	name string
+	fn   solver
+}{
+	{"Bisect", func(z *decimal.Big, f Func, a, b *decimal.Big, prec int32) (*decimal.Big, error) {
+		return Bisect(z, f, a, b, prec, 1000)
+	}},
+	{"Brent", func(z *decimal.Big, f Func, a, b *decimal.Big, prec int32) (*decimal.Big, error) {
+		return Brent(z, f, a, b, prec, 1000)
+	}},
+}
+
+func TestBracketed(t *testing.T) {
+	for _, s := range solvers {
+		for i, test := range [...]struct {
+			f    Func
+			a, b string
+			prec int32
+			want string
+		}{
+			0: {sqrt2, "0", "2", 20, "1.4142135623730950488"},
+			1: {cbrt10, "-5", "5", 30, "2.15443469003188372175929356652"},
+			2: {npv, "0", "1", 16, "0.1306623862918075"},
+			3: {sqrt2, "2", "0", 5, "1.4142"},
+		} {
+			z := new(decimal.Big)
+			if _, err := s.fn(z, test.f, newbig(t, test.a), newbig(t, test.b), test.prec); err != nil {
+				t.Fatalf("%s#%d: %v", s.name, i, err)
+			}
+			if want := newbig(t, test.want); z.Cmp(want) != 0 {
+				t.Fatalf(`%s#%d:
+wanted: %s
+got   : %s
+`, s.name, i, want, z)
+			}
+		}
+
+		if _, err := s.fn(new(decimal.Big), sqrt2, one, decimal.New(-1, 0), 10); err != ErrNotBracketed {
+			t.Fatalf("%s: wanted %v, got %v", s.name, ErrNotBracketed, err)
+		}
+	}
+}
+
+func TestNewton(t *testing.T) {
+	z := new(decimal.Big)
+	if _, err := Newton(z, sqrt2, dsqrt2, one, 40, 100); err != nil {
+		t.Fatal(err)
+	}
+	want := newbig(t, "1.414213562373095048801688724209698078570")
+	if z.Cmp(want) != 0 {
+		t.Fatalf(`
+wanted: %s
+got   : %s
+`, want, z)
+	}
+
+	if _, err := Newton(z, sqrt2, dsqrt2, new(decimal.Big), 10, 100); err != ErrZeroDerivative {
+		t.Fatalf("wanted %v, got %v", ErrZeroDerivative, err)
+	}
+	if _, err := Newton(z, sqrt2, dsqrt2, decimal.New(1, -100), 30, 3); err != ErrMaxIterations {
+		t.Fatalf("wanted %v, got %v", ErrMaxIterations, err)
+	}
+}
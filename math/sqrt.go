@@ -12,7 +12,7 @@ func Hypot(z, p, q *decimal.Big) *decimal.Big {
 	switch {
 	case p.IsInf(0), q.IsInf(0):
 		return z.SetInf(true)
-	case p.IsNaN(true), p.IsNaN(false), q.IsNaN(true), q.IsNaN(false):
+	case p.IsNaN(0), q.IsNaN(0):
 		return z.SetNaN(true)
 	}
 
@@ -34,10 +34,10 @@ func Sqrt(z, x *decimal.Big) *decimal.Big {
 			errors.New("math.Sqrt: cannot take square root of negative number"),
 		)
 	}
-	if snan := x.IsNaN(true); snan || x.IsNaN(false) {
+	if snan := x.IsNaN(-1); snan || x.IsNaN(+1) {
 		x.SetNaN(snan)
 		return signal(z,
-			decimal.InvalidOperation, decimal.ErrNaN{"square root of NaN"})
+			decimal.InvalidOperation, decimal.ErrNaN{Msg: "square root of NaN"})
 	}
 	if x.IsInf(1) {
 		return z.SetInf(false)
@@ -0,0 +1,310 @@
+// Package money implements amounts of money in a particular currency.
+//
+// A Money pairs a decimal amount with an ISO 4217 Currency. Every operation
+// rounds its result to the currency's minor unit (e.g., cents) and operations
+// on amounts in different currencies return an error instead of silently
+// mixing them.
+package money
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/calc"
+)
+
+// Money is an amount of money in a particular currency. The zero value is not
+// usable until it's been given a currency with SetAmount or Set.
+type Money struct {
+	// Context is the Money's contextual object. Its RoundingMode determines
+	// how results are rounded to the currency's minor unit.
+	Context decimal.Context
+
+	amount   decimal.Big
+	currency Currency
+}
+
+// ErrMismatch is returned when an operation is given amounts in two different
+// currencies.
+type ErrMismatch struct {
+	X, Y Currency
+}
+
+func (e ErrMismatch) Error() string {
+	return fmt.Sprintf("money: currency mismatch: %s and %s", e.X, e.Y)
+}
+
+var errNotFinite = errors.New("money: amount is not finite")
+
+// New returns a new Money with the amount, rounded to c's minor unit, and
+// currency. It's equivalent to new(Money).SetAmount(amount, c).
+func New(amount *decimal.Big, c Currency) (*Money, error) {
+	return new(Money).SetAmount(amount, c)
+}
+
+// SetAmount sets z to amount, rounded to c's minor unit, in the currency c and
+// returns z. If c is unknown, SetAmount returns ErrUnknownCurrency.
+func (z *Money) SetAmount(amount *decimal.Big, c Currency) (*Money, error) {
+	if _, ok := c.Scale(); !ok {
+		return z, ErrUnknownCurrency{Code: string(c)}
+	}
+	if amount.IsInf(0) || amount.IsNaN(0) {
+		return z, errNotFinite
+	}
+	z.currency = c
+	z.amount.Copy(amount)
+	return z.round(), nil
+}
+
+// Set sets z to x and returns z.
+func (z *Money) Set(x *Money) *Money {
+	if z != x {
+		z.currency = x.currency
+		z.amount.Copy(&x.amount)
+		z.round()
+	}
+	return z
+}
+
+// Amount returns a copy of x's amount.
+func (x *Money) Amount() *decimal.Big {
+	return new(decimal.Big).Copy(&x.amount)
+}
+
+// Currency returns x's currency.
+func (x *Money) Currency() Currency { return x.currency }
+
+// round rounds z's amount to its currency's minor unit using z's Context and
+// returns z.
+func (z *Money) round() *Money {
+	scale, _ := z.currency.Scale()
+	z.amount.Context = z.Context
+	z.amount.Quantize(scale)
+	return z
+}
+
+// check returns an error if x and y have different currencies.
+func check(x, y *Money) error {
+	if x.currency != y.currency {
+		return ErrMismatch{X: x.currency, Y: y.currency}
+	}
+	return nil
+}
+
+// Add sets z to x + y and returns z. If x and y have different currencies, z
+// is unchanged and Add returns ErrMismatch.
+func (z *Money) Add(x, y *Money) (*Money, error) {
+	if err := check(x, y); err != nil {
+		return z, err
+	}
+	z.amount.Context = z.Context
+	z.amount.Add(&x.amount, &y.amount)
+	z.currency = x.currency
+	return z.round(), nil
+}
+
+// Sub sets z to x - y and returns z. If x and y have different currencies, z
+// is unchanged and Sub returns ErrMismatch.
+func (z *Money) Sub(x, y *Money) (*Money, error) {
+	if err := check(x, y); err != nil {
+		return z, err
+	}
+	z.amount.Context = z.Context
+	z.amount.Sub(&x.amount, &y.amount)
+	z.currency = x.currency
+	return z.round(), nil
+}
+
+// Mul sets z to x * y, rounded to x's minor unit, and returns z. If x * y is
+// not finite, z is unchanged and Mul returns an error.
+func (z *Money) Mul(x *Money, y *decimal.Big) (*Money, error) {
+	// The product of an m-digit and an n-digit coefficient has at most m+n
+	// digits, so it's exact and only rounded once, to the minor unit.
+	p := new(decimal.Big)
+	p.Context = z.Context
+	p.Context.OperatingMode = decimal.GDA
+	p.Context.SetPrecision(int32(x.amount.Precision() + y.Precision()))
+	p.Mul(&x.amount, y)
+	return z.setFinite(p, x.currency)
+}
+
+// Quo sets z to x / y, rounded to x's minor unit, and returns z. If x / y is
+// not finite, e.g. because y is zero, z is unchanged and Quo returns an error.
+func (z *Money) Quo(x *Money, y *decimal.Big) (*Money, error) {
+	// Compute enough digits to reach the minor unit.
+	scale, _ := x.currency.Scale()
+	prec := calc.Adjusted(&x.amount) - calc.Adjusted(y) + 1 + int64(scale) + calc.GuardDigits
+	if prec < calc.GuardDigits {
+		prec = calc.GuardDigits
+	}
+
+	// Truncate the quotient so it's only rounded once, to the minor unit. If
+	// it's inexact, append a sticky digit so that, e.g., 0.004999... doesn't
+	// look like a tie.
+	q := new(decimal.Big)
+	q.Context = z.Context
+	q.Context.OperatingMode = decimal.GDA
+	q.Context.RoundingMode = decimal.ToZero
+	q.Context.SetPrecision(int32(prec))
+	q.Quo(&x.amount, y)
+	if q.Context.Conditions&decimal.Inexact != 0 {
+		q.Context.SetPrecision(int32(prec + 1))
+		q.Add(q, decimal.New(int64(q.Sign()), q.Scale()+1))
+	}
+	return z.setFinite(q, x.currency)
+}
+
+// setFinite sets z to amount, rounded to c's minor unit, and returns z. If
+// amount is not finite, z is unchanged and setFinite returns errNotFinite.
+// Unlike SetAmount, it doesn't check c.
+func (z *Money) setFinite(amount *decimal.Big, c Currency) (*Money, error) {
+	if amount.IsInf(0) || amount.IsNaN(0) {
+		return z, errNotFinite
+	}
+	z.amount.Copy(amount)
+	z.currency = c
+	return z.round(), nil
+}
+
+// Neg sets z to -x and returns z.
+func (z *Money) Neg(x *Money) *Money {
+	z.Set(x)
+	z.amount.Neg(&z.amount)
+	return z
+}
+
+// Abs sets z to |x| and returns z.
+func (z *Money) Abs(x *Money) *Money {
+	z.Set(x)
+	z.amount.Abs(&z.amount)
+	return z
+}
+
+// Cmp compares x and y and returns
+//
+//   -1 if x <  y
+//    0 if x == y
+//   +1 if x >  y
+//
+// If x and y have different currencies, Cmp returns ErrMismatch.
+func (x *Money) Cmp(y *Money) (int, error) {
+	if err := check(x, y); err != nil {
+		return 0, err
+	}
+	return x.amount.Cmp(&y.amount), nil
+}
+
+// Sign returns
+//
+//   -1 if x <  0
+//    0 if x == 0
+//   +1 if x >  0
+//
+func (x *Money) Sign() int { return x.amount.Sign() }
+
+// Allocate splits x into len(ratios) parts proportional to ratios without
+// losing any minor units. See decimal.Allocate for details.
+func (x *Money) Allocate(ratios []*decimal.Big) []*Money {
+	scale, _ := x.currency.Scale()
+	parts := decimal.Allocate(&x.amount, ratios, scale)
+	z := make([]*Money, len(parts))
+	for i, p := range parts {
+		z[i] = &Money{Context: x.Context, currency: x.currency}
+		z[i].amount.Copy(p)
+	}
+	return z
+}
+
+// String returns x's currency code followed by its amount with exactly as
+// many digits after the radix as the currency's minor unit. For example,
+// "USD 12.30" or "JPY -500".
+func (x *Money) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	scale, _ := x.currency.Scale()
+
+	// Format the amount as an integer number of minor units, then insert the
+	// radix.
+	var u big.Int
+	new(decimal.Big).Copy(&x.amount).SetScale(x.amount.Scale() - scale).Int(&u)
+	digits := u.String()
+	neg := strings.HasPrefix(digits, "-")
+	if neg {
+		digits = digits[1:]
+	}
+	if n := int(scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+
+	var b bytes.Buffer
+	b.WriteString(string(x.currency))
+	b.WriteByte(' ')
+	if neg {
+		b.WriteByte('-')
+	}
+	if scale > 0 {
+		i := len(digits) - int(scale)
+		b.WriteString(digits[:i])
+		b.WriteByte('.')
+		b.WriteString(digits[i:])
+	} else {
+		b.WriteString(digits)
+	}
+	return b.String()
+}
+
+// Parse parses s, which must be an ISO 4217 currency code and an amount
+// separated by whitespace, in either order; for example, "USD 12.30" or
+// "12.30 usd". The amount must not have more digits after the radix than the
+// currency's minor unit.
+func Parse(s string) (*Money, error) {
+	return new(Money).parse(s)
+}
+
+func (z *Money) parse(s string) (*Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return z, fmt.Errorf("money: invalid amount: %q", s)
+	}
+	code, num := fields[0], fields[1]
+	if len(code) != 3 || !isAlpha(code) {
+		code, num = num, code
+	}
+	c, err := ParseCurrency(code)
+	if err != nil {
+		return z, err
+	}
+	amount, ok := new(decimal.Big).SetString(num)
+	if !ok || amount.IsInf(0) || amount.IsNaN(0) {
+		return z, fmt.Errorf("money: invalid amount: %q", s)
+	}
+	if scale, _ := c.Scale(); amount.Scale() > scale {
+		return z, fmt.Errorf("money: %q has more digits than %s's minor unit", s, c)
+	}
+	return z.SetAmount(amount, c)
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (x *Money) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (z *Money) UnmarshalText(data []byte) error {
+	_, err := z.parse(string(data))
+	return err
+}
@@ -0,0 +1,177 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func newbig(t *testing.T, s string) *decimal.Big {
+	x, ok := new(decimal.Big).SetString(s)
+	if !ok {
+		t.Fatalf("bad input: %q", s)
+	}
+	return x
+}
+
+func newmoney(t *testing.T, s string) *Money {
+	m, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestParse(t *testing.T) {
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		0: {"USD 12.30", "USD 12.30"},
+		1: {"12.3 usd", "USD 12.30"},
+		2: {"JPY -500", "JPY -500"},
+		3: {"BHD 0.005", "BHD 0.005"},
+		4: {"  EUR   0  ", "EUR 0.00"},
+		5: {"USD -0.07", "USD -0.07"},
+		6: {"KWD 1e+3", "KWD 1000.000"},
+		7: {"CLF 12345678901234567890.1234", "CLF 12345678901234567890.1234"},
+	} {
+		m := newmoney(t, test.in)
+		if s := m.String(); s != test.out {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.out, s)
+		}
+	}
+
+	for i, s := range [...]string{
+		"", "USD", "USD 1 2", "XYZ 1.00", "USD 1.001", "JPY 1.5", "USD abc", "USD Inf",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Fatalf("#%d: %q: wanted error", i, s)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	x := newmoney(t, "USD 10.00")
+	y := newmoney(t, "USD 0.07")
+
+	z := new(Money)
+	if _, err := z.Add(x, y); err != nil || z.String() != "USD 10.07" {
+		t.Fatalf("Add: wanted USD 10.07, got %s (%v)", z, err)
+	}
+	if _, err := z.Sub(y, x); err != nil || z.String() != "USD -9.93" {
+		t.Fatalf("Sub: wanted USD -9.93, got %s (%v)", z, err)
+	}
+	if _, err := z.Mul(x, newbig(t, "0.0825")); err != nil || z.String() != "USD 0.82" {
+		t.Fatalf("Mul: wanted USD 0.82, got %s (%v)", z, err)
+	}
+	if _, err := z.Quo(x, decimal.New(3, 0)); err != nil || z.String() != "USD 3.33" {
+		t.Fatalf("Quo: wanted USD 3.33, got %s (%v)", z, err)
+	}
+	if _, err := z.Quo(newmoney(t, "BHD 100"), decimal.New(7, 0)); err != nil || z.String() != "BHD 14.286" {
+		t.Fatalf("Quo: wanted BHD 14.286, got %s (%v)", z, err)
+	}
+	if _, err := z.Mul(newmoney(t, "JPY 1000"), newbig(t, "0.0015")); err != nil || z.String() != "JPY 2" {
+		t.Fatalf("Mul: wanted JPY 2, got %s (%v)", z, err)
+	}
+
+	// Non-finite results are errors and leave z unchanged.
+	for i, test := range [...]struct {
+		op   func(z, x *Money, y *decimal.Big) (*Money, error)
+		x, y string
+	}{
+		0: {(*Money).Quo, "USD 10.00", "0"},
+		1: {(*Money).Quo, "USD 0.00", "0"},
+		2: {(*Money).Quo, "USD 10.00", "NaN"},
+		3: {(*Money).Mul, "USD 10.00", "NaN"},
+		4: {(*Money).Mul, "USD 10.00", "Inf"},
+		5: {(*Money).Mul, "USD 0.00", "-Inf"},
+	} {
+		if _, err := test.op(z, newmoney(t, test.x), newbig(t, test.y)); err == nil {
+			t.Fatalf("#%d: %s, %s: wanted an error", i, test.x, test.y)
+		}
+		if z.String() != "JPY 2" {
+			t.Fatalf("#%d: wanted z unchanged, got %s", i, z)
+		}
+	}
+
+	// The Context's RoundingMode controls rounding to the minor unit.
+	z.Context.RoundingMode = decimal.ToZero
+	if _, err := z.Mul(x, newbig(t, "0.0829")); err != nil || z.String() != "USD 0.82" {
+		t.Fatalf("Mul: wanted USD 0.82, got %s (%v)", z, err)
+	}
+
+	// So is the quotient: 1.00 / 200.0000001 is 0.0049999..., not 0.005.
+	z.Context.RoundingMode = decimal.ToNearestAway
+	if _, err := z.Quo(newmoney(t, "USD 1.00"), newbig(t, "200.0000001")); err != nil || z.String() != "USD 0.00" {
+		t.Fatalf("Quo: wanted USD 0.00, got %s (%v)", z, err)
+	}
+	if _, err := z.Quo(newmoney(t, "USD -1.00"), newbig(t, "199.9999999")); err != nil || z.String() != "USD -0.01" {
+		t.Fatalf("Quo: wanted USD -0.01, got %s (%v)", z, err)
+	}
+	z.Context.RoundingMode = decimal.ToZero
+
+	// The product is only rounded once, to the minor unit, even if it has
+	// more digits than the Context's precision.
+	const big = "USD 123456789012345678.91"
+	if _, err := z.Mul(newmoney(t, big), decimal.New(1, 0)); err != nil || z.String() != big {
+		t.Fatalf("Mul: wanted %s, got %s (%v)", big, z, err)
+	}
+
+	jpy := newmoney(t, "JPY 100")
+	if _, err := z.Add(x, jpy); err != (ErrMismatch{X: "USD", Y: "JPY"}) {
+		t.Fatalf("Add: wanted ErrMismatch, got %v", err)
+	}
+	if _, err := x.Cmp(jpy); err == nil {
+		t.Fatal("Cmp: wanted ErrMismatch")
+	}
+	if c, err := x.Cmp(y); err != nil || c != +1 {
+		t.Fatalf("Cmp: wanted +1, got %d (%v)", c, err)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	parts := newmoney(t, "USD 100").Allocate([]*decimal.Big{
+		decimal.New(1, 0), decimal.New(1, 0), decimal.New(1, 0),
+	})
+	want := [...]string{"USD 33.34", "USD 33.33", "USD 33.33"}
+	for i, p := range parts {
+		if p.String() != want[i] {
+			t.Fatalf("#%d: wanted %s, got %s", i, want[i], p)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	x := newmoney(t, "GBP 1234.5")
+	b, err := x.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var y Money
+	if err := y.UnmarshalText(b); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := x.Cmp(&y); err != nil || c != 0 {
+		t.Fatalf("wanted %s, got %s", x, &y)
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	for i, test := range [...]struct {
+		code  string
+		scale int32
+	}{
+		{"JPY", 0}, {"usd", 2}, {"BHD", 3}, {"CLF", 4},
+	} {
+		c, err := ParseCurrency(test.code)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if s, _ := c.Scale(); s != test.scale {
+			t.Fatalf("#%d: wanted %d, got %d", i, test.scale, s)
+		}
+	}
+	if _, err := ParseCurrency("XAU"); err == nil {
+		t.Fatal("wanted error for XAU")
+	}
+}
@@ -0,0 +1,239 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// ref is an exact reference implementation of the package's arithmetic. It
+// computes each result as a big.Rat and then rounds it to prec significant
+// digits using mode. It doesn't share any code with Big's rounding, so it can
+// be used to double-check it.
+type ref struct {
+	prec int32 // 0 means the result isn't rounded
+	mode RoundingMode
+}
+
+// refRat returns x as a big.Rat and whether x is finite or zero.
+func refRat(x *Big) (*big.Rat, bool) {
+	if x.IsNaN(0) || x.IsInf(0) {
+		return nil, false
+	}
+	return x.Rat(nil), true
+}
+
+// binary returns the rounded result of op applied to x and y, or nil if x or
+// y is not a finite number or zero.
+func (r ref) binary(x, y *Big, op func(z, x, y *big.Rat) *big.Rat) *Big {
+	xr, ok1 := refRat(x)
+	yr, ok2 := refRat(y)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	return r.round(op(new(big.Rat), xr, yr))
+}
+
+func (r ref) add(x, y *Big) *Big { return r.binary(x, y, (*big.Rat).Add) }
+func (r ref) sub(x, y *Big) *Big { return r.binary(x, y, (*big.Rat).Sub) }
+func (r ref) mul(x, y *Big) *Big { return r.binary(x, y, (*big.Rat).Mul) }
+
+// quo returns nil if y is zero.
+func (r ref) quo(x, y *Big) *Big {
+	if y.Sign() == 0 {
+		return nil
+	}
+	return r.binary(x, y, (*big.Rat).Quo)
+}
+
+// round returns q rounded to r.prec significant digits. If r.prec is 0, q must
+// have a finite decimal expansion.
+func (r ref) round(q *big.Rat) *Big {
+	if q.Sign() == 0 {
+		return New(0, 0)
+	}
+	neg := q.Sign() < 0
+	a := new(big.Rat).Abs(q)
+
+	// Find d such that 10^(d-1) <= a < 10^d, i.e., a has d digits before the
+	// radix.
+	d := int32(len(a.Num().String()) - len(a.Denom().String()))
+	for a.Cmp(refPow10(d)) >= 0 {
+		d++
+	}
+	for a.Cmp(refPow10(d-1)) < 0 {
+		d--
+	}
+
+	prec := r.prec
+	if prec == 0 {
+		// Find the shortest exact expansion.
+		for prec = 1; ; prec++ {
+			s := new(big.Rat).Mul(a, refPow10(prec-d))
+			if s.IsInt() {
+				break
+			}
+		}
+	}
+
+	// s = a * 10^(prec-d), so 10^(prec-1) <= s < 10^prec.
+	scale := prec - d
+	s := new(big.Rat).Mul(a, refPow10(scale))
+	n, rem := new(big.Int).QuoRem(s.Num(), s.Denom(), new(big.Int))
+
+	if rem.Sign() != 0 {
+		// Compare the remainder against one half: 2*rem <=> denom.
+		half := new(big.Int).Lsh(rem, 1).Cmp(s.Denom())
+		var inc bool
+		switch r.mode {
+		case ToNearestEven:
+			inc = half > 0 || half == 0 && n.Bit(0) == 1
+		case ToNearestAway:
+			inc = half >= 0
+		case ToZero:
+			inc = false
+		case AwayFromZero:
+			inc = true
+		case ToNegativeInf:
+			inc = neg
+		case ToPositiveInf:
+			inc = !neg
+		default:
+			panic(fmt.Sprintf("ref: unknown rounding mode: %s", r.mode))
+		}
+		if inc {
+			n.Add(n, oneInt)
+		}
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return new(Big).SetBigMantScale(n, scale)
+}
+
+func refPow10(n int32) *big.Rat {
+	if n >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Exp(tenInt, big.NewInt(int64(n)), nil))
+	}
+	return new(big.Rat).SetFrac(oneInt, new(big.Int).Exp(tenInt, big.NewInt(int64(-n)), nil))
+}
+
+func TestRef(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		prec int32
+		mode RoundingMode
+		want string
+	}{
+		0:  {"2.5", 1, ToNearestEven, "2"},
+		1:  {"3.5", 1, ToNearestEven, "4"},
+		2:  {"2.5", 1, ToNearestAway, "3"},
+		3:  {"-2.5", 1, ToNearestAway, "-3"},
+		4:  {"2.9", 1, ToZero, "2"},
+		5:  {"-2.9", 1, ToZero, "-2"},
+		6:  {"2.1", 1, AwayFromZero, "3"},
+		7:  {"-2.1", 1, AwayFromZero, "-3"},
+		8:  {"2.1", 1, ToPositiveInf, "3"},
+		9:  {"-2.9", 1, ToPositiveInf, "-2"},
+		10: {"2.9", 1, ToNegativeInf, "2"},
+		11: {"-2.1", 1, ToNegativeInf, "-3"},
+		12: {"9.96", 2, ToNearestEven, "10"},
+		13: {"0.00012345", 3, ToNearestEven, "0.000123"},
+		14: {"12345e10", 2, ToNearestEven, "1.2e14"},
+		15: {"1.5", 0, ToNearestEven, "1.5"},
+	} {
+		x, _ := new(Big).SetString(test.x)
+		want, _ := new(Big).SetString(test.want)
+		got := ref{prec: test.prec, mode: test.mode}.round(x.Rat(nil))
+		if got.Cmp(want) != 0 {
+			t.Fatalf("#%d: round(%s, %d, %s): wanted %s, got %s",
+				i, test.x, test.prec, test.mode, want, got)
+		}
+	}
+
+	third := ref{prec: 5}.quo(New(1, 0), New(3, 0))
+	if want := New(33333, 5); third.Cmp(want) != 0 {
+		t.Fatalf("quo(1, 3): wanted %s, got %s", want, third)
+	}
+	if z := (ref{prec: 5}).quo(New(1, 0), New(0, 0)); z != nil {
+		t.Fatalf("quo(1, 0): wanted nil, got %s", z)
+	}
+}
+
+// randBig returns a random decimal with up to 40 digits, so about half are
+// compact and half are inflated, and a scale in [-20, 20].
+func randBig(rng *rand.Rand) *Big {
+	n := 1 + rng.Intn(40)
+	digits := make([]byte, n)
+	for i := range digits {
+		digits[i] = byte('0' + rng.Intn(10))
+	}
+	s := string(digits)
+	if rng.Intn(2) == 0 {
+		s = "-" + s
+	}
+	s += fmt.Sprintf("e%d", rng.Intn(41)-20)
+	x, ok := new(Big).SetString(s)
+	if !ok {
+		panic(fmt.Sprintf("randBig: couldn't SetString(%q)", s))
+	}
+	return x
+}
+
+var refModes = [...]RoundingMode{
+	ToNearestEven, ToNearestAway, ToZero, AwayFromZero, ToNegativeInf, ToPositiveInf,
+}
+
+func TestBig_Differential(t *testing.T) {
+	n := 10000
+	if testing.Short() {
+		n = 1000
+	}
+	rng := rand.New(rand.NewSource(1))
+
+	for _, test := range [...]struct {
+		name string
+		op   func(z, x, y *Big) *Big
+		ref  func(r ref, x, y *Big) *Big
+	}{
+		{"Add", (*Big).Add, ref.add},
+		{"Sub", (*Big).Sub, ref.sub},
+		{"Mul", (*Big).Mul, ref.mul},
+		{"Quo", (*Big).Quo, ref.quo},
+		{"Round", func(z, x, _ *Big) *Big {
+			// Copy overwrites z's Context.
+			ctx := z.Context
+			z.Copy(x).Context = ctx
+			return z.Round(ctx.Precision())
+		}, func(r ref, x, _ *Big) *Big {
+			return r.round(x.Rat(nil))
+		}},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < n; i++ {
+				x, y := randBig(rng), randBig(rng)
+				r := ref{
+					prec: 1 + rng.Int31n(40),
+					mode: refModes[rng.Intn(len(refModes))],
+				}
+				want := test.ref(r, x, y)
+				if want == nil {
+					continue
+				}
+
+				z := new(Big)
+				z.Context.OperatingMode = GDA
+				z.Context.RoundingMode = r.mode
+				z.Context.SetPrecision(r.prec)
+				test.op(z, x, y)
+				if z.Cmp(want) != 0 {
+					t.Fatalf("#%d: %s(%s, %s) [prec: %d, mode: %s]\nwanted: %s\ngot   : %s",
+						i, strings.ToLower(test.name), x, y, r.prec, r.mode, want, z)
+				}
+			}
+		})
+	}
+}
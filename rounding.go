@@ -9,29 +9,41 @@ import (
 )
 
 func (z *Big) shouldInc(c int, pos, odd bool) bool {
-	switch r := z.Context.RoundingMode; r {
+	inc, ok := roundsUp(z.Context.RoundingMode, c, pos, odd)
+	if !ok {
+		z.signal(InvalidContext, fmt.Errorf("invalid rounding mode: %d", z.Context.RoundingMode))
+	}
+	return inc
+}
+
+// roundsUp reports whether a value that is being rounded using the rounding
+// mode r should have its magnitude incremented. c is the result of comparing
+// the discarded remainder against half a unit in the last place, pos is true
+// if the value is positive, and odd is true if the retained least significant
+// digit is odd. ok is false if r is invalid.
+func roundsUp(r RoundingMode, c int, pos, odd bool) (inc, ok bool) {
+	switch r {
 	case AwayFromZero:
-		return true
+		return true, true
 	case ToZero:
-		return false
+		return false, true
 	case ToPositiveInf:
-		return pos
+		return pos, true
 	case ToNegativeInf:
-		return !pos
+		return !pos, true
 	case ToNearestEven, ToNearestAway:
 		if c < 0 {
-			return false
+			return false, true
 		}
 		if c > 0 {
-			return true
+			return true, true
 		}
 		if r == ToNearestEven {
-			return odd
+			return odd, true
 		}
-		return true
+		return true, true
 	default:
-		z.signal(InvalidContext, fmt.Errorf("invalid rounding mode: %d", r))
-		return false
+		return false, false
 	}
 }
 
@@ -0,0 +1,81 @@
+package decimal
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// NullBig is a Big that may be NULL. It implements sql.Scanner and
+// driver.Valuer, so it can be used as a scan destination and a query
+// argument with any database/sql driver, similar to sql.NullString.
+//
+// Scan accepts the values SQLite returns for each of its storage classes:
+// string and []byte (TEXT and BLOB) are parsed with SetString, int64
+// (INTEGER) is converted exactly, and nil (NULL) sets Valid to false. float64
+// (REAL) is rejected unless Lossy is true, since a float64 column has usually
+// already lost precision.
+type NullBig struct {
+	Big   *Big
+	Valid bool // Valid is true if Big is not NULL
+
+	// Lossy allows Scan to accept float64 values. The float64 is converted to
+	// the shortest decimal that rounds to the same float64, so 0.1 is scanned
+	// as 0.1 and not as 0.1000000000000000055511151231257827021181583404541015625.
+	Lossy bool
+}
+
+// Scan implements sql.Scanner.
+func (n *NullBig) Scan(value interface{}) error {
+	if value == nil {
+		n.Valid = false
+		return nil
+	}
+	if n.Big == nil {
+		n.Big = new(Big)
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case int64:
+		n.Big.SetMantScale(v, 0)
+		n.Valid = true
+		return nil
+	case float64:
+		if !n.Lossy {
+			return fmt.Errorf("NullBig.Scan: float64 %g would lose precision; set Lossy to accept it", v)
+		}
+		str = strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Errorf("NullBig.Scan: unknown value: %#v", value)
+	}
+
+	if _, ok := n.Big.SetString(str); !ok {
+		n.Valid = false
+		if err := n.Big.Context.Err; err != nil {
+			return err
+		}
+		return fmt.Errorf("NullBig.Scan: invalid syntax: %q", str)
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer. It returns nil if n is not Valid or Big is
+// nil, and Big's String form otherwise.
+func (n NullBig) Value() (driver.Value, error) {
+	if !n.Valid || n.Big == nil {
+		return nil, nil
+	}
+	return n.Big.String(), nil
+}
+
+var (
+	_ sql.Scanner   = (*NullBig)(nil)
+	_ driver.Valuer = NullBig{}
+)
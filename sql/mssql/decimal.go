@@ -0,0 +1,109 @@
+// Package mssql encodes and decodes decimal.Big values in SQL Server's
+// DECIMAL and NUMERIC format, as sent by TDS and stored in backups and logs.
+package mssql
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// The format of a DECIMAL(p, s) is a sign byte, 1 if the value is positive or
+// zero and 0 if it's negative, followed by the magnitude of the value's
+// coefficient at scale s as a little-endian integer. The integer is 4, 8, 12,
+// or 16 bytes, depending on p. In TDS the value is preceded by a length byte,
+// which is not part of the format.
+
+// MaxPrecision is the max precision, p in DECIMAL(p, s).
+const MaxPrecision = 38
+
+// BinarySize returns the length in bytes of a DECIMAL(p, s), including the
+// sign byte.
+func BinarySize(p int) int {
+	switch {
+	case p <= 9:
+		return 1 + 4
+	case p <= 19:
+		return 1 + 8
+	case p <= 28:
+		return 1 + 12
+	default:
+		return 1 + 16
+	}
+}
+
+func checkTypmod(p, s int) error {
+	if p < 1 || p > MaxPrecision || s < 0 || s > p {
+		return fmt.Errorf("invalid DECIMAL(%d, %d)", p, s)
+	}
+	return nil
+}
+
+// AppendBinary appends x to dst in the format of a DECIMAL(p, s) and returns
+// the extended buffer. x is rounded to s digits after the decimal point using
+// its Context's RoundingMode; x itself is not modified. AppendBinary returns
+// an error if x is NaN or an infinity, or if it has more than p-s digits
+// before the decimal point.
+func AppendBinary(dst []byte, x *decimal.Big, p, s int) ([]byte, error) {
+	if err := checkTypmod(p, s); err != nil {
+		return dst, fmt.Errorf("AppendBinary: %v", err)
+	}
+	if x.IsNaN(0) || x.IsInf(0) {
+		return dst, fmt.Errorf("AppendBinary: DECIMAL does not accept %s", x)
+	}
+
+	v := new(decimal.Big).Copy(x).Quantize(int32(s))
+	m := v.SetScale(0).Int(nil)
+	sign := byte(1)
+	if m.Sign() < 0 {
+		sign = 0
+	}
+	m.Abs(m)
+	if n := len(m.String()); m.Sign() != 0 && n > p {
+		return dst, fmt.Errorf("AppendBinary: %s has %d digits, DECIMAL(%d, %d) allows %d", x, n, p, s, p)
+	}
+
+	size := BinarySize(p)
+	dst = append(dst, sign)
+	b := m.Bytes() // big-endian
+	for i := len(b) - 1; i >= 0; i-- {
+		dst = append(dst, b[i])
+	}
+	for i := len(b); i < size-1; i++ {
+		dst = append(dst, 0)
+	}
+	return dst, nil
+}
+
+// DecodeBinary sets z to the DECIMAL(p, s) at the beginning of src and
+// returns the number of bytes read, which is BinarySize(p).
+func DecodeBinary(z *decimal.Big, src []byte, p, s int) (int, error) {
+	if err := checkTypmod(p, s); err != nil {
+		return 0, fmt.Errorf("DecodeBinary: %v", err)
+	}
+	size := BinarySize(p)
+	if len(src) < size {
+		return 0, fmt.Errorf("DecodeBinary: DECIMAL(%d, %d) needs %d bytes, got %d", p, s, size, len(src))
+	}
+	if src[0] > 1 {
+		return 0, fmt.Errorf("DecodeBinary: invalid sign: %#02x", src[0])
+	}
+
+	b := make([]byte, size-1)
+	for i := range b {
+		b[i] = src[size-1-i] // little-endian to big-endian
+	}
+	m := new(big.Int).SetBytes(b)
+	if n := len(m.String()); m.Sign() != 0 && n > p {
+		return 0, fmt.Errorf("DecodeBinary: %d digits is too many for DECIMAL(%d, %d)", n, p, s)
+	}
+	if src[0] == 0 {
+		m.Neg(m)
+	}
+	z.SetBigMantScale(m, int32(s))
+	if m.Sign() == 0 {
+		z.SetScale(int32(s))
+	}
+	return size, nil
+}
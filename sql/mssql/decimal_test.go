@@ -0,0 +1,78 @@
+package mssql
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+var binaryTests = [...]struct {
+	in   string
+	p, s int
+	hex  string
+}{
+	0: {"123.45", 5, 2, "01" + "39300000"},
+	1: {"-123.45", 5, 2, "00" + "39300000"},
+	2: {"0", 10, 2, "01" + "0000000000000000"},
+	3: {"1234567890.1234", 18, 4, "01" + "f22fce733a0b0000"},
+	4: {"1.5", 20, 3, "01" + "dc0500000000000000000000"},
+	5: {"99999999999999999999999999999999999999", 38, 0, "01" + "ffffffff3f228a097ac4865aa84c3b4b"},
+	6: {"-0.00000000000000000000000000000000000001", 38, 38, "00" + "01000000000000000000000000000000"},
+}
+
+func TestAppendBinary(t *testing.T) {
+	for i, test := range binaryTests {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := AppendBinary(nil, x, test.p, test.s)
+		if err != nil {
+			t.Fatalf("#%d: AppendBinary(%s, %d, %d): %v", i, test.in, test.p, test.s, err)
+		}
+		if got := hex.EncodeToString(b); got != test.hex {
+			t.Fatalf("#%d: AppendBinary(%s, %d, %d): wanted %s, got %s",
+				i, test.in, test.p, test.s, test.hex, got)
+		}
+	}
+
+	for i, test := range [...]struct {
+		in   string
+		p, s int
+	}{
+		0: {"1000", 5, 2},
+		1: {"NaN", 5, 2},
+		2: {"-Inf", 5, 2},
+		3: {"1", 39, 0},
+		4: {"1", 5, 6},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		if _, err := AppendBinary(nil, x, test.p, test.s); err == nil {
+			t.Fatalf("#%d: AppendBinary(%s, %d, %d): expected an error", i, test.in, test.p, test.s)
+		}
+	}
+}
+
+func TestDecodeBinary(t *testing.T) {
+	for i, test := range binaryTests {
+		b, _ := hex.DecodeString(test.hex)
+		z := new(decimal.Big)
+		n, err := DecodeBinary(z, b, test.p, test.s)
+		if err != nil {
+			t.Fatalf("#%d: DecodeBinary(%s, %d, %d): %v", i, test.hex, test.p, test.s, err)
+		}
+		if n != len(b) {
+			t.Fatalf("#%d: DecodeBinary(%s): read %d bytes, wanted %d", i, test.hex, n, len(b))
+		}
+		want, _ := new(decimal.Big).SetString(test.in)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("#%d: DecodeBinary(%s, %d, %d): wanted %s, got %s",
+				i, test.hex, test.p, test.s, want, z)
+		}
+	}
+
+	for i, s := range [...]string{"01393000", "0239300000", "01a0860100"} {
+		b, _ := hex.DecodeString(s)
+		if _, err := DecodeBinary(new(decimal.Big), b, 5, 2); err == nil {
+			t.Fatalf("#%d: DecodeBinary(%s): expected an error", i, s)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package mysql
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// The packed binary format of a DECIMAL(M, D), used by the binary log and by
+// InnoDB, stores the M-D integral and D fractional digits in groups of nine
+// decimal digits. Each full group is a 4-byte big-endian integer. Leftover
+// integral digits form a shorter leading group and leftover fractional digits
+// a shorter trailing group, each stored in as few bytes as will hold it:
+//
+//     digits  1  2  3  4  5  6  7  8  9
+//     bytes   1  1  2  2  3  3  4  4  4
+//
+// A negative number has every byte inverted. Finally, the most significant bit
+// of the first byte is inverted, so the encodings of a DECIMAL(M, D) sort the
+// same way as their values.
+
+const digitsPerGroup = 9
+
+var dig2bytes = [digitsPerGroup + 1]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+
+// BinarySize returns the length in bytes of a packed DECIMAL(m, d).
+func BinarySize(m, d int) int {
+	intg, frac := m-d, d
+	return intg/digitsPerGroup*4 + dig2bytes[intg%digitsPerGroup] +
+		frac/digitsPerGroup*4 + dig2bytes[frac%digitsPerGroup]
+}
+
+func checkTypmod(m, d int) error {
+	if m < 1 || m > MaxPrecision || d < 0 || d > MaxScale || d > m {
+		return fmt.Errorf("invalid DECIMAL(%d, %d)", m, d)
+	}
+	return nil
+}
+
+// AppendBinary appends x to dst in the packed binary format of a
+// DECIMAL(m, d) and returns the extended buffer. x is rounded to d digits
+// after the decimal point using its Context's RoundingMode; x itself is not
+// modified. AppendBinary returns an error if x is NaN or an infinity, or if it
+// has more than m-d digits before the decimal point.
+func AppendBinary(dst []byte, x *decimal.Big, m, d int) ([]byte, error) {
+	if err := checkTypmod(m, d); err != nil {
+		return dst, fmt.Errorf("AppendBinary: %v", err)
+	}
+	if x.IsNaN(0) || x.IsInf(0) {
+		return dst, fmt.Errorf("AppendBinary: DECIMAL does not accept %s", x)
+	}
+
+	v := new(decimal.Big).Copy(x).Quantize(int32(d))
+	neg := v.Sign() < 0
+	s := v.SetScale(0).Int(nil)
+	s.Abs(s)
+
+	// s is now the m digits of the number, left-padded with zeros.
+	digits := s.String()
+	if s.Sign() == 0 {
+		digits = ""
+	}
+	if len(digits) > m {
+		return dst, &LengthError{Part: "precision", N: len(digits), max: m}
+	}
+	digits = strings.Repeat("0", m-len(digits)) + digits
+
+	start := len(dst)
+	intg := m - d
+	put := func(group string) {
+		n := 0
+		for i := 0; i < len(group); i++ {
+			n = n*10 + int(group[i]-'0')
+		}
+		for i := dig2bytes[len(group)] - 1; i >= 0; i-- {
+			dst = append(dst, byte(n>>(8*uint(i))))
+		}
+	}
+	if r := intg % digitsPerGroup; r != 0 {
+		put(digits[:r])
+	}
+	for i := intg % digitsPerGroup; i < m; i += digitsPerGroup {
+		end := i + digitsPerGroup
+		if end > m {
+			end = m
+		}
+		put(digits[i:end])
+	}
+
+	b := dst[start:]
+	if neg {
+		for i := range b {
+			b[i] = ^b[i]
+		}
+	}
+	b[0] ^= 0x80
+	return dst, nil
+}
+
+// DecodeBinary sets z to the packed binary DECIMAL(m, d) at the beginning of
+// src and returns the number of bytes read, which is BinarySize(m, d).
+func DecodeBinary(z *decimal.Big, src []byte, m, d int) (int, error) {
+	if err := checkTypmod(m, d); err != nil {
+		return 0, fmt.Errorf("DecodeBinary: %v", err)
+	}
+	size := BinarySize(m, d)
+	if len(src) < size {
+		return 0, fmt.Errorf("DecodeBinary: DECIMAL(%d, %d) needs %d bytes, got %d", m, d, size, len(src))
+	}
+
+	var mask byte
+	if src[0]&0x80 == 0 {
+		mask = 0xFF
+	}
+	b := src[:size]
+	first := true
+	get := func(ndigits int) (uint64, error) {
+		var n uint64
+		nbytes := dig2bytes[ndigits]
+		for i := 0; i < nbytes; i++ {
+			c := b[i]
+			if first {
+				c ^= 0x80
+				first = false
+			}
+			n = n<<8 | uint64(c^mask)
+		}
+		b = b[nbytes:]
+		if n >= pow10[ndigits] {
+			return 0, fmt.Errorf("DecodeBinary: invalid %d-digit group: %d", ndigits, n)
+		}
+		return n, nil
+	}
+
+	var (
+		mant  = new(big.Int)
+		group = new(big.Int)
+		intg  = m - d
+	)
+	for i := 0; i < m; {
+		n := digitsPerGroup
+		if i == 0 && intg%digitsPerGroup != 0 {
+			n = intg % digitsPerGroup
+		} else if i >= intg && m-i < digitsPerGroup {
+			n = m - i
+		}
+		g, err := get(n)
+		if err != nil {
+			return 0, err
+		}
+		mant.Mul(mant, bigPow10[n]).Add(mant, group.SetUint64(g))
+		i += n
+	}
+	if mask != 0 {
+		mant.Neg(mant)
+	}
+	z.SetBigMantScale(mant, int32(d))
+	if mant.Sign() == 0 {
+		z.SetScale(int32(d))
+	}
+	return size, nil
+}
+
+var (
+	pow10    [digitsPerGroup + 1]uint64
+	bigPow10 [digitsPerGroup + 1]*big.Int
+)
+
+func init() {
+	pow10[0] = 1
+	for i := 1; i < len(pow10); i++ {
+		pow10[i] = pow10[i-1] * 10
+	}
+	for i, p := range pow10 {
+		bigPow10[i] = new(big.Int).SetUint64(p)
+	}
+}
@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+var binaryTests = [...]struct {
+	in   string
+	m, d int
+	hex  string
+}{
+	// The first two are from the comment above decimal2bin in MySQL's
+	// strings/decimal.c.
+	0: {"1234567890.1234", 14, 4, "810dfb38d204d2"},
+	1: {"-1234567890.1234", 14, 4, "7ef204c72dfb2d"},
+	2: {"0", 10, 2, "8000000000"},
+	3: {"1", 1, 0, "81"},
+	4: {"-1", 1, 0, "7e"},
+	5: {"0.5", 1, 1, "85"},
+	6: {"123456789", 9, 0, "875bcd15"},
+	7: {"123456789.987654321", 18, 9, "875bcd153ade68b1"},
+	8: {"99.99", 4, 2, "e363"},
+}
+
+func TestAppendBinary(t *testing.T) {
+	for i, test := range binaryTests {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := AppendBinary(nil, x, test.m, test.d)
+		if err != nil {
+			t.Fatalf("#%d: AppendBinary(%s, %d, %d): %v", i, test.in, test.m, test.d, err)
+		}
+		if got := hex.EncodeToString(b); got != test.hex {
+			t.Fatalf("#%d: AppendBinary(%s, %d, %d): wanted %s, got %s",
+				i, test.in, test.m, test.d, test.hex, got)
+		}
+		if n := BinarySize(test.m, test.d); n != len(b) {
+			t.Fatalf("#%d: BinarySize(%d, %d): wanted %d, got %d", i, test.m, test.d, len(b), n)
+		}
+	}
+}
+
+func TestDecodeBinary(t *testing.T) {
+	for i, test := range binaryTests {
+		b, _ := hex.DecodeString(test.hex + "ff") // trailing data must be ignored
+		z := new(decimal.Big)
+		n, err := DecodeBinary(z, b, test.m, test.d)
+		if err != nil {
+			t.Fatalf("#%d: DecodeBinary(%s, %d, %d): %v", i, test.hex, test.m, test.d, err)
+		}
+		if n != len(b)-1 {
+			t.Fatalf("#%d: DecodeBinary(%s): read %d bytes, wanted %d", i, test.hex, n, len(b)-1)
+		}
+		want, _ := new(decimal.Big).SetString(test.in)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("#%d: DecodeBinary(%s, %d, %d): wanted %s, got %s",
+				i, test.hex, test.m, test.d, want, z)
+		}
+	}
+}
+
+func TestAppendBinaryErrors(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		m, d int
+	}{
+		0: {"100", 2, 0},
+		1: {"99.995", 4, 2},
+		2: {"NaN", 10, 2},
+		3: {"Inf", 10, 2},
+		4: {"1", 66, 0},
+		5: {"1", 5, 6},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		if _, err := AppendBinary(nil, x, test.m, test.d); err == nil {
+			t.Fatalf("#%d: AppendBinary(%s, %d, %d): expected an error", i, test.in, test.m, test.d)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package mysql provides a simple wrapper around a decimal.Big type, allowing
+// it to be used in MySQL and MariaDB queries. It ensures the decimal fits
+// inside the limits of the DECIMAL type, and it encodes and decodes the packed
+// binary DECIMAL format used by the binary log.
+package mysql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ericlagergren/decimal"
+)
+
+const (
+	MaxPrecision = 65 // max digits, M in DECIMAL(M, D)
+	MaxScale     = 30 // max digits after the decimal point, D in DECIMAL(M, D)
+)
+
+// LengthError is returned when a decimal has too many digits for MySQL. Part
+// is "fractional" if it has more than MaxScale digits after the decimal point
+// and "precision" if it has more than MaxPrecision digits in total.
+type LengthError struct {
+	Part string // "precision" or "fractional"
+	N    int    // length of invalid part
+	max  int
+}
+
+func (e LengthError) Error() string {
+	return fmt.Sprintf("%s (%d digits) is too long (%d max)", e.Part, e.N, e.max)
+}
+
+// Decimal is a MySQL DECIMAL. Its zero value is valid for use with both
+// Value and Scan.
+type Decimal struct {
+	V     *decimal.Big
+	Round bool // round if the decimal exceeds the bounds for DECIMAL
+	Zero  bool // return "0" if V == nil
+}
+
+// Value implements driver.Valuer. MySQL cannot store NaN or infinities, so
+// Value returns an error if V is either. If Round is true, V is rounded with
+// its Context's RoundingMode to fit; V itself is not modified.
+func (d *Decimal) Value() (driver.Value, error) {
+	if d.V == nil {
+		if d.Zero {
+			return "0", nil
+		}
+		return nil, nil
+	}
+	if d.V.IsNaN(0) {
+		return nil, errors.New("Decimal.Value: DECIMAL does not accept NaN")
+	}
+	if d.V.IsInf(0) {
+		return nil, errors.New("Decimal.Value: DECIMAL does not accept Infinities")
+	}
+
+	v := d.V
+	if sl := int(v.Scale()); sl > MaxScale {
+		// Trailing zeros don't count, so quantizing them away is exact.
+		if fl := fracDigits(v); fl > MaxScale && !d.Round {
+			return nil, &LengthError{Part: "fractional", N: fl, max: MaxScale}
+		}
+		v = new(decimal.Big).Copy(v).Quantize(MaxScale)
+	}
+	if il, fl := digits(v); il+fl > MaxPrecision {
+		if !d.Round || il > MaxPrecision {
+			return nil, &LengthError{Part: "precision", N: il + fl, max: MaxPrecision}
+		}
+		v = new(decimal.Big).Copy(v).Quantize(int32(MaxPrecision - il))
+	}
+	return v.Text('f', -1), nil
+}
+
+// digits returns the number of digits before and after the decimal point
+// needed to store the finite x.
+func digits(x *decimal.Big) (il, fl int) {
+	if x.Sign() == 0 {
+		return 0, 0
+	}
+	il = x.Precision() - int(x.Scale())
+	if il < 0 {
+		il = 0
+	}
+	if x.Scale() > 0 {
+		fl = fracDigits(x)
+	}
+	return il, fl
+}
+
+// fracDigits returns the number of digits after the decimal point of the
+// finite x, not counting trailing zeros.
+func fracDigits(x *decimal.Big) int {
+	s := x.Text('f', -1)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// Scan implements sql.Scanner. val must be the text format of a DECIMAL as a
+// string or []byte, which is what MySQL drivers return.
+func (d *Decimal) Scan(val interface{}) error {
+	var str string
+	switch v := val.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return fmt.Errorf("Decimal.Scan: unknown value: %#v", val)
+	}
+	if d.V == nil {
+		d.V = new(decimal.Big)
+	}
+	if _, ok := d.V.SetString(str); !ok {
+		if err := d.V.Context.Err; err != nil {
+			return err
+		}
+		return fmt.Errorf("Decimal.Scan: invalid syntax: %q", str)
+	}
+	return nil
+}
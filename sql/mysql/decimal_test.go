@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestDecimal_Value(t *testing.T) {
+	long := strings.Repeat("9", 40) + "." + strings.Repeat("1", 30)
+	for i, test := range [...]struct {
+		in    string
+		round bool
+		want  string // "" if Value should fail
+	}{
+		0: {"1234.5678", false, "1234.5678"},
+		1: {"-0.00001", false, "-0.00001"},
+		2: {"1E+3", false, "1000"},
+		3: {"0." + strings.Repeat("1", 31), false, ""},
+		4: {"0." + strings.Repeat("1", 31), true, "0." + strings.Repeat("1", 30)},
+		5: {long, false, ""},
+		6: {long, true, strings.Repeat("9", 40) + "." + strings.Repeat("1", 25)},
+		7: {strings.Repeat("9", 66), true, ""},
+		8: {"NaN", true, ""},
+		9: {"-Inf", true, ""},
+		// Only significant fractional digits count.
+		10: {"0E-40", false, "0"},
+		11: {"1.5" + strings.Repeat("0", 38), false, "1.5"},
+		12: {strings.Repeat("9", 60) + "." + strings.Repeat("0", 40), false, strings.Repeat("9", 60)},
+		13: {"0." + strings.Repeat("0", 30) + "1000", false, ""},
+		14: {"-0." + strings.Repeat("1", 30) + strings.Repeat("0", 9), false, "-0." + strings.Repeat("1", 30)},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		d := Decimal{V: x, Round: test.round}
+		v, err := d.Value()
+		if test.want == "" {
+			if err == nil {
+				t.Fatalf("#%d: Value(%s): expected an error, got %v", i, test.in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d: Value(%s): %v", i, test.in, err)
+		}
+		if v.(string) != test.want {
+			t.Fatalf("#%d: Value(%s): wanted %s, got %s", i, test.in, test.want, v)
+		}
+	}
+
+	var d Decimal
+	if v, err := d.Value(); v != nil || err != nil {
+		t.Fatalf("Value(nil): wanted (nil, nil), got (%v, %v)", v, err)
+	}
+	d.Zero = true
+	if v, err := d.Value(); v != "0" || err != nil {
+		t.Fatalf("Value(nil) with Zero: wanted (0, nil), got (%v, %v)", v, err)
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	for i, v := range [...]interface{}{"-12.50", []byte("-12.50")} {
+		var d Decimal
+		if err := d.Scan(v); err != nil {
+			t.Fatalf("#%d: Scan(%#v): %v", i, v, err)
+		}
+		if d.V.Cmp(decimal.New(-125, 1)) != 0 {
+			t.Fatalf("#%d: Scan(%#v): wanted -12.5, got %s", i, v, d.V)
+		}
+	}
+	var d Decimal
+	if err := d.Scan(1.5); err == nil {
+		t.Fatal("Scan(float64): expected an error")
+	}
+}
@@ -0,0 +1,158 @@
+// Package oracle encodes and decodes decimal.Big values in Oracle's internal
+// NUMBER format, as found in data files, redo logs, and the output of DUMP.
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// The internal format of a NUMBER is an exponent byte followed by up to 20
+// base-100 mantissa digits, most significant first. The value is
+//
+//     sum(digits[i] * 100^(exp-i))
+//
+// A positive number's exponent byte is 193+exp and each digit is stored plus
+// one, so digits are 1 through 100. A negative number's exponent byte is
+// 62-exp, each digit d is stored as 101-d, and, unless there are 20 digits,
+// the mantissa is followed by a 102 terminator. Trailing zero digits are not
+// stored. Zero is the single byte 0x80, positive infinity is 0xFF 0x65, and
+// negative infinity is the single byte 0x00. There is no NaN.
+
+const (
+	MaxDigits = 20 // max base-100 mantissa digits
+	MinExp    = -65
+	MaxExp    = 62
+
+	posBias    = 193
+	negBias    = 62
+	terminator = 102
+)
+
+var (
+	zeroNumber = []byte{0x80}
+	pinfNumber = []byte{0xFF, 0x65}
+	ninfNumber = []byte{0x00}
+)
+
+// AppendBinary appends x to dst in Oracle's NUMBER format and returns the
+// extended buffer. It returns an error if x is NaN, has more than MaxDigits
+// base-100 digits, or is too large or too small for a NUMBER.
+func AppendBinary(dst []byte, x *decimal.Big) ([]byte, error) {
+	switch {
+	case x.IsNaN(0):
+		return dst, errors.New("AppendBinary: NUMBER does not accept NaN")
+	case x.IsInf(+1):
+		return append(dst, pinfNumber...), nil
+	case x.IsInf(-1):
+		return append(dst, ninfNumber...), nil
+	case x.Sign() == 0:
+		return append(dst, zeroNumber...), nil
+	}
+
+	m := new(decimal.Big).Copy(x).SetScale(0).Int(nil)
+	neg := m.Sign() < 0
+	s := m.Abs(m).String()
+
+	// Make the base-10 exponent even, then left-pad to an even number of
+	// digits.
+	exp10 := -int(x.Scale())
+	if exp10%2 != 0 {
+		s += "0"
+		exp10--
+	}
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	exp := len(s)/2 - 1 + exp10/2
+
+	// Trailing zero digits are not stored.
+	s = strings.TrimRight(s, "0")
+	if len(s)%2 != 0 {
+		s += "0"
+	}
+	ndigits := len(s) / 2
+	if ndigits > MaxDigits {
+		return dst, fmt.Errorf("AppendBinary: %s has %d base-100 digits (%d max)", x, ndigits, MaxDigits)
+	}
+	if exp < MinExp || exp > MaxExp {
+		return dst, fmt.Errorf("AppendBinary: %s is out of range for NUMBER", x)
+	}
+
+	if neg {
+		dst = append(dst, byte(negBias-exp))
+	} else {
+		dst = append(dst, byte(posBias+exp))
+	}
+	for i := 0; i < ndigits; i++ {
+		d := (s[2*i]-'0')*10 + s[2*i+1] - '0'
+		if neg {
+			dst = append(dst, 101-d)
+		} else {
+			dst = append(dst, d+1)
+		}
+	}
+	if neg && ndigits < MaxDigits {
+		dst = append(dst, terminator)
+	}
+	return dst, nil
+}
+
+// DecodeBinary sets z to the NUMBER in src, which must contain exactly one
+// NUMBER.
+func DecodeBinary(z *decimal.Big, src []byte) error {
+	switch {
+	case len(src) == 0:
+		return errors.New("DecodeBinary: empty NUMBER")
+	case len(src) == 1 && src[0] == zeroNumber[0]:
+		z.SetMantScale(0, 0)
+		return nil
+	case len(src) == 2 && src[0] == pinfNumber[0] && src[1] == pinfNumber[1]:
+		z.SetInf(false)
+		return nil
+	case len(src) == 1 && src[0] == ninfNumber[0]:
+		z.SetInf(true)
+		return nil
+	}
+
+	neg := src[0]&0x80 == 0
+	var exp int
+	digits := src[1:]
+	if neg {
+		exp = negBias - int(src[0])
+		if n := len(digits); n > 0 && digits[n-1] == terminator {
+			digits = digits[:n-1]
+		}
+	} else {
+		exp = int(src[0]) - posBias
+	}
+	if len(digits) == 0 || len(digits) > MaxDigits {
+		return fmt.Errorf("DecodeBinary: invalid NUMBER length: %d", len(src))
+	}
+
+	var (
+		m     = new(big.Int)
+		digit = new(big.Int)
+		hund  = big.NewInt(100)
+	)
+	for _, b := range digits {
+		d := int64(b) - 1
+		if neg {
+			d = 101 - int64(b)
+		}
+		if d < 0 || d > 99 {
+			return fmt.Errorf("DecodeBinary: invalid NUMBER digit: %#02x", b)
+		}
+		m.Mul(m, hund).Add(m, digit.SetInt64(d))
+	}
+	if neg {
+		m.Neg(m)
+	}
+	// m * 100^(exp-len(digits)+1) is the value.
+	z.SetBigMantScale(m, int32(-2*(exp-len(digits)+1)))
+	return nil
+}
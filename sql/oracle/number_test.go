@@ -0,0 +1,73 @@
+package oracle
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// Encodings from Oracle's DUMP function.
+var numberTests = [...]struct {
+	in   string
+	dump []byte
+}{
+	0:  {"0", []byte{128}},
+	1:  {"1", []byte{193, 2}},
+	2:  {"-1", []byte{62, 100, 102}},
+	3:  {"100", []byte{194, 2}},
+	4:  {"123", []byte{194, 2, 24}},
+	5:  {"0.5", []byte{192, 51}},
+	6:  {"-123.45", []byte{61, 100, 78, 56, 102}},
+	7:  {"1234567.89", []byte{196, 2, 24, 46, 68, 90}},
+	8:  {"1E+125", []byte{255, 11}},
+	9:  {"1E-130", []byte{128, 2}},
+	10: {"-1E-130", []byte{127, 100, 102}},
+	11: {"Inf", []byte{255, 101}},
+	12: {"-Inf", []byte{0}},
+	13: {"12345678901234567890123456789012345678", []byte{
+		211, 13, 35, 57, 79, 91, 13, 35, 57, 79, 91, 13, 35, 57, 79, 91, 13, 35, 57, 79,
+	}},
+	14: {"-12345678901234567890123456789012345678", []byte{
+		44, 89, 67, 45, 23, 11, 89, 67, 45, 23, 11, 89, 67, 45, 23, 11, 89, 67, 45, 23, 102,
+	}},
+}
+
+func TestAppendBinary(t *testing.T) {
+	for i, test := range numberTests {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := AppendBinary(nil, x)
+		if err != nil {
+			t.Fatalf("#%d: AppendBinary(%s): %v", i, test.in, err)
+		}
+		if !bytes.Equal(b, test.dump) {
+			t.Fatalf("#%d: AppendBinary(%s): wanted %v, got %v", i, test.in, test.dump, b)
+		}
+	}
+
+	for i, s := range [...]string{"NaN", "1E+126", "1E-131", "12345678901234567890123456789012345678901"} {
+		x, _ := new(decimal.Big).SetString(s)
+		if _, err := AppendBinary(nil, x); err == nil {
+			t.Fatalf("#%d: AppendBinary(%s): expected an error", i, s)
+		}
+	}
+}
+
+func TestDecodeBinary(t *testing.T) {
+	for i, test := range numberTests {
+		z := new(decimal.Big)
+		if err := DecodeBinary(z, test.dump); err != nil {
+			t.Fatalf("#%d: DecodeBinary(%v): %v", i, test.dump, err)
+		}
+		want, _ := new(decimal.Big).SetString(test.in)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("#%d: DecodeBinary(%v): wanted %s, got %s", i, test.dump, want, z)
+		}
+	}
+
+	for i, b := range [...][]byte{nil, {193}, {193, 0}, {193, 102}, {62, 1, 102}} {
+		if err := DecodeBinary(new(decimal.Big), b); err == nil {
+			t.Fatalf("#%d: DecodeBinary(%v): expected an error", i, b)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// The binary format of a NUMERIC, used by binary COPY and the binary protocol,
+// is
+//
+//     ndigits int16
+//     weight  int16
+//     sign    uint16
+//     dscale  uint16
+//     digits  [ndigits]int16
+//
+// digits are base-10000 digits, most significant first, and the value is
+//
+//     sum(digits[i] * 10000^(weight-i))
+//
+// dscale is the number of digits after the decimal point that are displayed,
+// and trailing zero digits are not stored. NaN and, since PostgreSQL 14, the
+// infinities are encoded in sign and have no digits.
+
+const (
+	numericPos  = 0x0000
+	numericNeg  = 0x4000
+	numericNaN  = 0xC000
+	numericPinf = 0xD000
+	numericNinf = 0xF000
+
+	nbase       = 10000
+	decDigits   = 4 // base-10 digits in a base-10000 digit
+	headerLen   = 8
+	maxDscale   = 0x3FFF
+	maxNdigits  = math.MaxInt16
+	maxWeight   = math.MaxInt16
+	minWeight   = math.MinInt16
+	nbaseDigits = "0000"
+)
+
+var bigNbase = big.NewInt(nbase)
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning d.V in
+// PostgreSQL's binary NUMERIC format. Unlike Value, it never rounds: it
+// returns a *LengthError if d.V is too long for a NUMERIC. If d.V is nil,
+// MarshalBinary encodes 0 if d.Zero is true and returns an error otherwise.
+func (d *Decimal) MarshalBinary() ([]byte, error) {
+	v := d.V
+	if v == nil {
+		if !d.Zero {
+			return nil, errors.New("Decimal.MarshalBinary: nil decimal")
+		}
+		v = new(decimal.Big)
+	}
+
+	switch {
+	case v.IsNaN(0):
+		return numericHeader(0, 0, numericNaN, 0), nil
+	case v.IsInf(+1):
+		return numericHeader(0, 0, numericPinf, 0), nil
+	case v.IsInf(-1):
+		return numericHeader(0, 0, numericNinf, 0), nil
+	}
+
+	scale := int(v.Scale())
+	dscale := scale
+	if dscale < 0 {
+		dscale = 0
+	}
+	if dscale > MaxFractionalDigits {
+		return nil, &LengthError{Part: "fractional", N: dscale, max: MaxFractionalDigits}
+	}
+
+	if v.Sign() == 0 {
+		return numericHeader(0, 0, numericPos, dscale), nil
+	}
+	sign := uint16(numericPos)
+	if v.Signbit() {
+		sign = numericNeg
+	}
+
+	// The coefficient's digits followed by enough zeros to make the exponent
+	// a multiple of 4, then left-padded to a multiple of 4 digits.
+	m := new(decimal.Big).Copy(v).SetScale(0).Int(nil)
+	s := m.Abs(m).String()
+	if scale < 0 {
+		s += strings.Repeat("0", -scale)
+		scale = 0
+	}
+	if r := scale % decDigits; r != 0 {
+		s += nbaseDigits[r:]
+		scale += decDigits - r
+	}
+	if r := len(s) % decDigits; r != 0 {
+		s = nbaseDigits[r:] + s
+	}
+
+	ndigits := len(s) / decDigits
+	weight := ndigits - 1 - scale/decDigits
+	if weight > maxWeight {
+		il := len(strings.TrimLeft(s, "0")) - scale
+		return nil, &LengthError{Part: "integral", N: il, max: MaxIntegralDigits}
+	}
+
+	// Trailing zero digits are not stored.
+	for ndigits > 0 && s[(ndigits-1)*decDigits:ndigits*decDigits] == nbaseDigits {
+		ndigits--
+	}
+	if ndigits > maxNdigits || weight < minWeight {
+		return nil, fmt.Errorf("Decimal.MarshalBinary: %d base-%d digits is too long", ndigits, nbase)
+	}
+
+	b := numericHeader(ndigits, weight, sign, dscale)
+	for i := 0; i < ndigits; i++ {
+		n, _ := strconv.Atoi(s[i*decDigits : (i+1)*decDigits])
+		b = append(b, byte(n>>8), byte(n))
+	}
+	return b, nil
+}
+
+// numericHeader returns the header of a binary NUMERIC with enough capacity
+// for ndigits digits.
+func numericHeader(ndigits, weight int, sign uint16, dscale int) []byte {
+	b := make([]byte, headerLen, headerLen+2*ndigits)
+	binary.BigEndian.PutUint16(b[0:], uint16(ndigits))
+	binary.BigEndian.PutUint16(b[2:], uint16(int16(weight)))
+	binary.BigEndian.PutUint16(b[4:], sign)
+	binary.BigEndian.PutUint16(b[6:], uint16(dscale))
+	return b
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, setting d.V to the
+// PostgreSQL binary NUMERIC in data. Digits beyond the display scale, which
+// the server never sends, are truncated.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	if len(data) < headerLen {
+		return fmt.Errorf("Decimal.UnmarshalBinary: short header: %d bytes", len(data))
+	}
+	var (
+		ndigits = int(int16(binary.BigEndian.Uint16(data[0:])))
+		weight  = int(int16(binary.BigEndian.Uint16(data[2:])))
+		sign    = binary.BigEndian.Uint16(data[4:])
+		dscale  = int(binary.BigEndian.Uint16(data[6:]))
+	)
+	if ndigits < 0 || len(data) != headerLen+2*ndigits {
+		return fmt.Errorf("Decimal.UnmarshalBinary: %d digits in %d bytes", ndigits, len(data))
+	}
+	if dscale > maxDscale {
+		return fmt.Errorf("Decimal.UnmarshalBinary: invalid dscale: %d", dscale)
+	}
+
+	if d.V == nil {
+		d.V = new(decimal.Big)
+	}
+	switch sign {
+	case numericPos, numericNeg:
+		// OK
+	case numericNaN:
+		d.V.SetNaN(false)
+		return nil
+	case numericPinf, numericNinf:
+		d.V.SetInf(sign == numericNinf)
+		return nil
+	default:
+		return fmt.Errorf("Decimal.UnmarshalBinary: invalid sign: %#04x", sign)
+	}
+
+	m := new(big.Int)
+	digit := new(big.Int)
+	for i := 0; i < ndigits; i++ {
+		n := binary.BigEndian.Uint16(data[headerLen+2*i:])
+		if n >= nbase {
+			return fmt.Errorf("Decimal.UnmarshalBinary: invalid digit: %d", n)
+		}
+		m.Mul(m, bigNbase).Add(m, digit.SetUint64(uint64(n)))
+	}
+
+	// m * 10^exp is the value. Rescale m so that exp == -dscale.
+	exp := decDigits * (weight - ndigits + 1)
+	if shift := exp + dscale; shift > 0 {
+		m.Mul(m, digit.Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	} else if shift < 0 {
+		m.Quo(m, digit.Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil))
+	}
+	if sign == numericNeg {
+		m.Neg(m)
+	}
+	d.V.SetBigMantScale(m, int32(dscale))
+	return nil
+}
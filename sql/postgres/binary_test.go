@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+var binaryTests = [...]struct {
+	in  string
+	hex string // ndigits, weight, sign, dscale, digits
+}{
+	0:  {"0", "0000" + "0000" + "0000" + "0000"},
+	1:  {"1", "0001" + "0000" + "0000" + "0000" + "0001"},
+	2:  {"-1", "0001" + "0000" + "4000" + "0000" + "0001"},
+	3:  {"12345.678", "0003" + "0001" + "0000" + "0003" + "0001" + "0929" + "1a7c"},
+	4:  {"-12345.678", "0003" + "0001" + "4000" + "0003" + "0001" + "0929" + "1a7c"},
+	5:  {"10000", "0001" + "0001" + "0000" + "0000" + "0001"},
+	6:  {"1E+8", "0001" + "0002" + "0000" + "0000" + "0001"},
+	7:  {"0.0001", "0001" + "ffff" + "0000" + "0004" + "0001"},
+	8:  {"0.00012", "0002" + "ffff" + "0000" + "0005" + "0001" + "07d0"},
+	9:  {"1.50", "0002" + "0000" + "0000" + "0002" + "0001" + "1388"},
+	10: {"123456789012345678901234567890", "0008" + "0007" + "0000" + "0000" + "000c" + "0d80" + "1ed2" + "04d2" + "162e" + "2334" + "0d80" + "1ed2"},
+	11: {"NaN", "0000" + "0000" + "c000" + "0000"},
+	12: {"Inf", "0000" + "0000" + "d000" + "0000"},
+	13: {"-Inf", "0000" + "0000" + "f000" + "0000"},
+}
+
+func TestDecimal_MarshalBinary(t *testing.T) {
+	for i, test := range binaryTests {
+		x, ok := new(decimal.Big).SetString(test.in)
+		if !ok {
+			t.Fatalf("#%d: invalid decimal: %q", i, test.in)
+		}
+		d := Decimal{V: x}
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("#%d: MarshalBinary(%s): %v", i, test.in, err)
+		}
+		if got := hex.EncodeToString(b); got != test.hex {
+			t.Fatalf("#%d: MarshalBinary(%s): wanted %s, got %s", i, test.in, test.hex, got)
+		}
+	}
+}
+
+func TestDecimal_UnmarshalBinary(t *testing.T) {
+	for i, test := range binaryTests {
+		b, _ := hex.DecodeString(test.hex)
+		var d Decimal
+		if err := d.UnmarshalBinary(b); err != nil {
+			t.Fatalf("#%d: UnmarshalBinary(%s): %v", i, test.hex, err)
+		}
+		want, _ := new(decimal.Big).SetString(test.in)
+		if want.IsNaN(0) {
+			if !d.V.IsNaN(0) {
+				t.Fatalf("#%d: UnmarshalBinary(%s): wanted NaN, got %s", i, test.hex, d.V)
+			}
+			continue
+		}
+		if d.V.Cmp(want) != 0 {
+			t.Fatalf("#%d: UnmarshalBinary(%s): wanted %s, got %s", i, test.hex, want, d.V)
+		}
+		if want.IsFinite() && want.Scale() > 0 && d.V.Scale() != want.Scale() {
+			t.Fatalf("#%d: UnmarshalBinary(%s): wanted scale %d, got %d",
+				i, test.hex, want.Scale(), d.V.Scale())
+		}
+	}
+
+	for i, s := range [...]string{
+		"",
+		"0001000000000000",     // missing digit
+		"0000000012340000",     // bad sign
+		"0001000000000000ffff", // digit >= 10000
+		"000000000000ffff",     // dscale too large
+	} {
+		b, _ := hex.DecodeString(s)
+		var d Decimal
+		if err := d.UnmarshalBinary(b); err == nil {
+			t.Fatalf("#%d: UnmarshalBinary(%s): expected an error", i, s)
+		}
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	for i, v := range [...]interface{}{"12.5", []byte("12.5")} {
+		var d Decimal
+		if err := d.Scan(v); err != nil {
+			t.Fatalf("#%d: Scan(%#v): %v", i, v, err)
+		}
+		if s := d.V.String(); s != "12.5" {
+			t.Fatalf("#%d: Scan(%#v): wanted 12.5, got %s", i, v, s)
+		}
+	}
+	var d Decimal
+	if err := d.Scan(int64(1)); err == nil {
+		t.Fatal("Scan(int64): expected an error")
+	}
+}
@@ -73,10 +73,16 @@ func (d *Decimal) Value() (driver.Value, error) {
 	return v.String(), nil
 }
 
-// Scan implements sql.Scanner.
+// Scan implements sql.Scanner. val must be the text format of a DECIMAL as a
+// string or []byte. Use UnmarshalBinary to decode the binary format.
 func (d *Decimal) Scan(val interface{}) error {
-	str, ok := val.(string)
-	if !ok {
+	var str string
+	switch v := val.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
 		return fmt.Errorf("Decimal.Scan: unknown value: %#v", val)
 	}
 	if d.V == nil {
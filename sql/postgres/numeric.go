@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// MaxPrecision is the maximum precision of a NUMERIC(p, s) column.
+const MaxPrecision = 1000
+
+// OverflowError is returned from Numeric.Value when a value does not fit in a
+// NUMERIC(p, s) column. It is the error the server would return with SQLSTATE
+// 22003, numeric_value_out_of_range.
+type OverflowError struct {
+	Column string // Numeric.Column
+	P, S   int    // the column's precision and scale
+	Inf    bool   // the value is an infinity
+}
+
+func (e OverflowError) Error() string {
+	msg := "numeric field overflow: "
+	if e.Column != "" {
+		msg = fmt.Sprintf("column %q: %s", e.Column, msg)
+	}
+	if e.Inf {
+		return fmt.Sprintf("%sA field with precision %d, scale %d cannot hold an infinite value",
+			msg, e.P, e.S)
+	}
+	if e.P == e.S {
+		return fmt.Sprintf("%sA field with precision %d, scale %d must round to an absolute value less than 1",
+			msg, e.P, e.S)
+	}
+	return fmt.Sprintf("%sA field with precision %d, scale %d must round to an absolute value less than 10^%d",
+		msg, e.P, e.S, e.P-e.S)
+}
+
+// Numeric is a PostgreSQL NUMERIC(p, s). Value rounds V to the column's scale
+// and checks it against the column's precision before it is sent to the
+// server. Its zero value is valid for use with Scan, but Value requires P.
+type Numeric struct {
+	V      *decimal.Big
+	P, S   int    // precision and scale, as in NUMERIC(P, S)
+	Column string // column name used in errors
+
+	// RoundingMode, if non-nil, is used to round V to S digits after the
+	// decimal point. Otherwise, V is rounded like the server rounds, half
+	// away from zero, which is ToNearestAway.
+	RoundingMode *decimal.RoundingMode
+}
+
+// Value implements driver.Valuer. If V is nil, Value returns nil. If V,
+// rounded to S digits after the decimal point, has more than P-S digits
+// before it, Value returns an *OverflowError. V is not modified.
+func (n *Numeric) Value() (driver.Value, error) {
+	if n.P < 1 || n.P > MaxPrecision {
+		return nil, fmt.Errorf("Numeric.Value: precision %d must be between 1 and %d", n.P, MaxPrecision)
+	}
+	if n.V == nil {
+		return nil, nil
+	}
+	if n.V.IsNaN(0) {
+		return "NaN", nil
+	}
+	if n.V.IsInf(0) {
+		return nil, &OverflowError{Column: n.Column, P: n.P, S: n.S, Inf: true}
+	}
+
+	v := new(decimal.Big).Copy(n.V)
+	v.Context.RoundingMode = decimal.ToNearestAway
+	if n.RoundingMode != nil {
+		v.Context.RoundingMode = *n.RoundingMode
+	}
+	v.Quantize(int32(n.S))
+
+	// v must be less than 10^(P-S), so it can have at most P-S digits before
+	// the decimal point.
+	if v.Sign() != 0 && v.Precision()-int(v.Scale()) > n.P-n.S {
+		return nil, &OverflowError{Column: n.Column, P: n.P, S: n.S}
+	}
+	return v.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the same values as Decimal.Scan and
+// does not check V against the column's precision and scale.
+func (n *Numeric) Scan(val interface{}) error {
+	d := Decimal{V: n.V}
+	if err := d.Scan(val); err != nil {
+		return err
+	}
+	n.V = d.V
+	return nil
+}
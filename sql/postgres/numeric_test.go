@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestNumeric_Value(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		p, s int
+		mode decimal.RoundingMode
+		want string // "" if Value should overflow
+	}{
+		0:  {"1234.567", 12, 2, decimal.ToNearestAway, "1234.57"},
+		1:  {"1234.565", 12, 2, decimal.ToNearestAway, "1234.57"},
+		2:  {"1234.565", 12, 2, decimal.ToNearestEven, "1234.56"},
+		3:  {"-1234.565", 12, 2, decimal.ToNearestAway, "-1234.57"},
+		4:  {"1234.569", 12, 2, decimal.ToZero, "1234.56"},
+		5:  {"9999999999.99", 12, 2, decimal.ToNearestAway, "9999999999.99"},
+		6:  {"9999999999.995", 12, 2, decimal.ToNearestAway, ""},
+		7:  {"10000000000", 12, 2, decimal.ToNearestAway, ""},
+		8:  {"0.5", 2, 2, decimal.ToNearestAway, "0.5"},
+		9:  {"1", 2, 2, decimal.ToNearestAway, ""},
+		10: {"0.001", 3, 2, decimal.ToNearestAway, "0"},
+		11: {"12", 3, 0, decimal.ToNearestAway, "12"},
+		12: {"12.5", 2, 0, decimal.ToNearestAway, "13"},
+		13: {"99.5", 2, 0, decimal.ToNearestAway, ""},
+		14: {"NaN", 5, 2, decimal.ToNearestAway, "NaN"},
+		15: {"Inf", 5, 2, decimal.ToNearestAway, ""},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		n := Numeric{
+			V: x, P: test.p, S: test.s, Column: "amount",
+			RoundingMode: &test.mode,
+		}
+		v, err := n.Value()
+		if test.want == "" {
+			e, ok := err.(*OverflowError)
+			if !ok {
+				t.Fatalf("#%d: Value(%s): wanted *OverflowError, got (%v, %v)", i, test.in, v, err)
+			}
+			if e.Column != "amount" || e.P != test.p || e.S != test.s {
+				t.Fatalf("#%d: Value(%s): bad error: %#v", i, test.in, e)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d: Value(%s): %v", i, test.in, err)
+		}
+		got, _ := new(decimal.Big).SetString(v.(string))
+		want, _ := new(decimal.Big).SetString(test.want)
+		if got.IsNaN(0) != want.IsNaN(0) || !got.IsNaN(0) && got.Cmp(want) != 0 {
+			t.Fatalf("#%d: Value(%s): wanted %s, got %s", i, test.in, test.want, v)
+		}
+		if x.String() != newString(test.in) {
+			t.Fatalf("#%d: Value modified V: %s", i, x)
+		}
+	}
+}
+
+func TestNumeric_ValueDefaultMode(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		want string
+	}{
+		0: {"0.125", "0.13"},
+		1: {"-0.125", "-0.13"},
+		2: {"1234.565", "1234.57"},
+		3: {"0.124", "0.12"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		v, err := (&Numeric{V: x, P: 6, S: 2}).Value()
+		if err != nil {
+			t.Fatalf("#%d: Value(%s): %v", i, test.in, err)
+		}
+		if v != test.want {
+			t.Fatalf("#%d: Value(%s): wanted %s, got %s", i, test.in, test.want, v)
+		}
+	}
+}
+
+func newString(s string) string {
+	x, _ := new(decimal.Big).SetString(s)
+	return x.String()
+}
+
+func TestNumeric_ValueErrors(t *testing.T) {
+	var n Numeric
+	if _, err := n.Value(); err == nil {
+		t.Fatal("Value with P == 0: expected an error")
+	}
+
+	n = Numeric{V: decimal.New(1234, 0), P: 3, S: 0, Column: "qty"}
+	_, err := n.Value()
+	const want = `column "qty": numeric field overflow: ` +
+		`A field with precision 3, scale 0 must round to an absolute value less than 10^3`
+	if err == nil || err.Error() != want {
+		t.Fatalf("wanted %q, got %v", want, err)
+	}
+}
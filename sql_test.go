@@ -0,0 +1,66 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestNullBig_Scan(t *testing.T) {
+	for i, test := range [...]struct {
+		v     interface{}
+		lossy bool
+		want  string // "" if Scan should fail
+		valid bool
+	}{
+		0:  {"12.345", false, "12.345", true},
+		1:  {[]byte("-0.5"), false, "-0.5", true},
+		2:  {int64(-42), false, "-42", true},
+		3:  {int64(1<<63 - 1), false, "9223372036854775807", true},
+		4:  {0.1, true, "0.1", true},
+		5:  {1e300, true, "1e+300", true},
+		6:  {0.1, false, "", false},
+		7:  {nil, false, "<nil>", false},
+		8:  {"abc", false, "", false},
+		9:  {true, false, "", false},
+		10: {"NaN", false, "NaN", true},
+	} {
+		var n NullBig
+		n.Lossy = test.lossy
+		err := n.Scan(test.v)
+		if test.want == "" {
+			if err == nil {
+				t.Fatalf("#%d: Scan(%#v): expected an error", i, test.v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d: Scan(%#v): %v", i, test.v, err)
+		}
+		if n.Valid != test.valid {
+			t.Fatalf("#%d: Scan(%#v): wanted Valid == %t", i, test.v, test.valid)
+		}
+		if got := n.Big.String(); n.Valid && got != test.want {
+			t.Fatalf("#%d: Scan(%#v): wanted %s, got %s", i, test.v, test.want, got)
+		}
+	}
+}
+
+func TestNullBig_Value(t *testing.T) {
+	for i, test := range [...]struct {
+		n    NullBig
+		want driver.Value
+	}{
+		0: {NullBig{}, nil},
+		1: {NullBig{Big: New(125, 1)}, nil},
+		2: {NullBig{Big: New(125, 1), Valid: true}, "12.5"},
+		3: {NullBig{Valid: true}, nil},
+	} {
+		v, err := test.n.Value()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if v != test.want {
+			t.Fatalf("#%d: wanted %#v, got %#v", i, test.want, v)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package suite
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The decTest format is described at
+// http://speleotrove.com/decimal/dtfile.html
+//
+// Each line of a decTest file is blank, a comment starting with "--", a
+// directive, or a test. A directive is a keyword followed by a colon and a
+// value and applies to every test after it:
+//
+//     precision:   9
+//     rounding:    half_up
+//     maxExponent: 384
+//
+// A test is an id, an operation, one or more operands, "->", the result, and
+// zero or more conditions:
+//
+//     addx003 add '5.75' '3.3' -> 9.05
+//     divx001 divide 1 3 -> 0.333333333 Inexact Rounded
+//
+// Tokens may be quoted with ' or ", in which case a doubled quote stands for
+// the quote itself.
+
+// Directives are the decTest directives in effect for a DecCase.
+type Directives struct {
+	Precision   int
+	Rounding    string // lower case, e.g. "half_even"
+	MaxExponent int
+	MinExponent int
+	Extended    bool
+	Clamp       bool
+}
+
+// DecCase is a single test from a decTest file.
+type DecCase struct {
+	ID         string
+	Op         string // lower case, e.g. "add" or "squareroot"
+	Inputs     []Data
+	Output     Data     // "?" if the result is undefined
+	Conditions []string // lower case, e.g. "inexact" or "division_by_zero"
+	Directives
+}
+
+func (c DecCase) String() string {
+	var b bytes.Buffer
+	b.WriteString(c.ID)
+	b.WriteByte(' ')
+	b.WriteString(c.Op)
+	for _, in := range c.Inputs {
+		b.WriteByte(' ')
+		b.WriteString(quoteDecTest(string(in)))
+	}
+	b.WriteString(" -> ")
+	b.WriteString(quoteDecTest(string(c.Output)))
+	for _, cond := range c.Conditions {
+		b.WriteByte(' ')
+		b.WriteString(cond)
+	}
+	return b.String()
+}
+
+func quoteDecTest(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t'\"") && !strings.Contains(s, "--") {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// ParseDecTest returns the tests in decTest form read from r. Each DecCase
+// carries the directives in effect when it was read. The default directives
+// are those of the decTest spec: precision 9, half_up rounding, and an
+// exponent range of ±384 with extended arithmetic.
+//
+// "version" directives are ignored and "dectest" directives, which include
+// another file, are not followed.
+func ParseDecTest(r io.Reader) (cases []DecCase, err error) {
+	d := Directives{
+		Precision:   9,
+		Rounding:    "half_up",
+		MaxExponent: 384,
+		MinExponent: -383,
+		Extended:    true,
+	}
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		toks, err := tokenize(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if len(toks) == 0 {
+			continue
+		}
+
+		// Test ids never contain a colon.
+		if i := strings.IndexByte(toks[0], ':'); i >= 0 {
+			key := strings.ToLower(toks[0][:i])
+			val := strings.TrimSpace(toks[0][i+1:] + " " + strings.Join(toks[1:], " "))
+			if err := d.set(key, val); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			continue
+		}
+
+		c, err := parseDecTest(toks, d)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		cases = append(cases, c)
+	}
+	return cases, s.Err()
+}
+
+func (d *Directives) set(key, val string) (err error) {
+	switch key {
+	case "precision":
+		d.Precision, err = strconv.Atoi(val)
+	case "rounding":
+		d.Rounding = strings.ToLower(val)
+	case "maxexponent":
+		d.MaxExponent, err = strconv.Atoi(val)
+	case "minexponent":
+		d.MinExponent, err = strconv.Atoi(val)
+	case "extended":
+		d.Extended, err = parseFlag(val)
+	case "clamp":
+		d.Clamp, err = parseFlag(val)
+	case "version", "dectest":
+		// Ignored.
+	default:
+		return fmt.Errorf("unknown directive: %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %q", key, val)
+	}
+	return nil
+}
+
+func parseFlag(val string) (bool, error) {
+	switch val {
+	case "0":
+		return false, nil
+	case "1":
+		return true, nil
+	default:
+		return false, strconv.ErrSyntax
+	}
+}
+
+func parseDecTest(toks []string, d Directives) (DecCase, error) {
+	arrow := -1
+	for i, tok := range toks {
+		if tok == "->" {
+			arrow = i
+			break
+		}
+	}
+	// id op operand... -> result condition...
+	if arrow < 3 || arrow == len(toks)-1 {
+		return DecCase{}, fmt.Errorf("invalid test: %q", strings.Join(toks, " "))
+	}
+
+	c := DecCase{
+		ID:         toks[0],
+		Op:         strings.ToLower(toks[1]),
+		Inputs:     make([]Data, arrow-2),
+		Output:     Data(toks[arrow+1]),
+		Directives: d,
+	}
+	for i, tok := range toks[2:arrow] {
+		c.Inputs[i] = Data(tok)
+	}
+	for _, tok := range toks[arrow+2:] {
+		c.Conditions = append(c.Conditions, strings.ToLower(tok))
+	}
+	return c, nil
+}
+
+// tokenize splits a line of a decTest file into tokens, removing quotes and
+// comments.
+func tokenize(line string) (toks []string, err error) {
+	for i := 0; i < len(line); {
+		switch ch := line[i]; {
+		case ch == ' ' || ch == '\t':
+			i++
+		case strings.HasPrefix(line[i:], "--"):
+			return toks, nil
+		case ch == '\'' || ch == '"':
+			var tok []byte
+			for i++; ; i++ {
+				if i >= len(line) {
+					return nil, fmt.Errorf("unterminated string: %q", line)
+				}
+				if line[i] == ch {
+					if i+1 < len(line) && line[i+1] == ch {
+						i++
+					} else {
+						i++
+						break
+					}
+				}
+				tok = append(tok, line[i])
+			}
+			toks = append(toks, string(tok))
+		default:
+			j := i
+			for j < len(line) && line[j] != ' ' && line[j] != '\t' {
+				j++
+			}
+			toks = append(toks, line[i:j])
+			i = j
+		}
+	}
+	return toks, nil
+}
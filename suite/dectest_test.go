@@ -0,0 +1,84 @@
+package suite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDecTest(t *testing.T) {
+	const in = `-- A comment.
+version: 2.59
+
+precision:   16
+rounding:    Half_Even
+addx001 add 1 '2' -> 3 -- a trailing comment
+ADDX002 ADD '1E+2' "2" -> '102'
+quox001 quantize 'it''s' "say ""hi""" -> '--' Inexact Rounded
+
+extended: 0
+clamp:    1
+dvix001 divideint 1 3 -> ?
+`
+	d := Directives{
+		Precision:   16,
+		Rounding:    "half_even",
+		MaxExponent: 384,
+		MinExponent: -383,
+		Extended:    true,
+	}
+	d2 := d
+	d2.Extended = false
+	d2.Clamp = true
+	want := []DecCase{
+		{ID: "addx001", Op: "add", Inputs: []Data{"1", "2"}, Output: "3", Directives: d},
+		{ID: "ADDX002", Op: "add", Inputs: []Data{"1E+2", "2"}, Output: "102", Directives: d},
+		{
+			ID: "quox001", Op: "quantize", Inputs: []Data{"it's", `say "hi"`}, Output: "--",
+			Conditions: []string{"inexact", "rounded"}, Directives: d,
+		},
+		{ID: "dvix001", Op: "divideint", Inputs: []Data{"1", "3"}, Output: "?", Directives: d2},
+	}
+
+	cases, err := ParseDecTest(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cases, want) {
+		t.Fatalf("wanted: %#v\ngot   : %#v", want, cases)
+	}
+
+	// String must quote whatever tokenize unquotes.
+	for i, c := range cases {
+		c2, err := ParseDecTest(strings.NewReader(c.String()))
+		if err != nil {
+			t.Fatalf("#%d: ParseDecTest(%q): %v", i, c, err)
+		}
+		c2[0].Directives = c.Directives
+		if !reflect.DeepEqual(c2[0], c) {
+			t.Fatalf("#%d: %q\nwanted: %#v\ngot   : %#v", i, c, c, c2[0])
+		}
+	}
+}
+
+func TestParseDecTest_Errors(t *testing.T) {
+	for i, in := range [...]string{
+		0: "addx001 add '1 -> 2",
+		1: `addx001 add "1' -> 2`,
+		2: "precision: nine",
+		3: "extended: yes",
+		4: "bogus: 1",
+		5: "addx001 add 1 2",
+		6: "addx001 add -> 2",
+		7: "addx001 add 1 ->",
+	} {
+		// The error is reported on the second line.
+		_, err := ParseDecTest(strings.NewReader("-- comment\n" + in))
+		if err == nil {
+			t.Fatalf("#%d: %q: wanted an error", i, in)
+		}
+		if !strings.HasPrefix(err.Error(), "line 2: ") {
+			t.Fatalf("#%d: %q: wanted an error on line 2, got %v", i, in, err)
+		}
+	}
+}
@@ -7,9 +7,11 @@ package suite
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"strconv"
 	"strings"
 )
 
@@ -64,33 +66,72 @@ type Case struct {
 	Excep  Exception
 }
 
-// TODO(eric): String should print the same format as the input
-
+// String returns c in .fptest form, so ParseCase(c.String()) returns c. Since
+// the .fptest form has a single Underflow exception, Underflow is always
+// written as "u".
 func (c Case) String() string {
-	return fmt.Sprintf("%s%d [%s, %s]: %s(%s) = %s %s",
-		c.Prefix, c.Prec, c.Trap, c.Mode, c.Op,
-		join(c.Inputs, ", "), c.Output, c.Excep)
+	b := make([]byte, 0, 64)
+	b = append(b, c.Prefix...)
+	b = strconv.AppendInt(b, int64(c.Prec), 10)
+	b = append(b, opToVal[c.Op]...)
+	b = append(b, ' ')
+	b = append(b, modeToVal[c.Mode]...)
+	b = append(b, ' ')
+	if c.Trap != None {
+		b = c.Trap.appendVal(b)
+		b = append(b, ' ')
+	}
+	for _, in := range c.Inputs {
+		b = append(b, in...)
+		b = append(b, ' ')
+	}
+	b = append(b, "-> "...)
+	b = append(b, c.Output...)
+	if c.Excep != None {
+		b = append(b, ' ')
+		b = c.Excep.appendVal(b)
+	}
+	return string(b)
 }
 
-func join(a []Data, sep Data) Data {
-	if len(a) == 0 {
-		return ""
-	}
-	if len(a) == 1 {
-		return a[0]
+// WriteCases writes cases to w in .fptest form, one per line. It returns an
+// error, without writing anything, if a Case can't be represented in .fptest
+// form.
+func WriteCases(w io.Writer, cases []Case) error {
+	for i, c := range cases {
+		if err := c.check(); err != nil {
+			return fmt.Errorf("case #%d: %v", i, err)
+		}
 	}
-	n := len(sep) * (len(a) - 1)
-	for i := 0; i < len(a); i++ {
-		n += len(a[i])
+	bw := bufio.NewWriter(w)
+	for _, c := range cases {
+		bw.WriteString(c.String())
+		bw.WriteByte('\n')
 	}
+	return bw.Flush()
+}
 
-	b := make([]byte, n)
-	bp := copy(b, a[0])
-	for _, s := range a[1:] {
-		bp += copy(b[bp:], sep)
-		bp += copy(b[bp:], s)
+// check returns an error if c can't be written in .fptest form.
+func (c Case) check() error {
+	if c.Prefix != "d" && c.Prefix != "b" {
+		return fmt.Errorf("invalid prefix: %q", c.Prefix)
+	}
+	if c.Prec < 0 {
+		return fmt.Errorf("invalid precision: %d", c.Prec)
+	}
+	if _, ok := opToVal[c.Op]; !ok {
+		return fmt.Errorf("invalid op: %s", c.Op)
+	}
+	if _, ok := modeToVal[c.Mode]; !ok {
+		return fmt.Errorf("invalid mode: %s", c.Mode)
+	}
+	if len(c.Inputs) == 0 {
+		return errors.New("no inputs")
 	}
-	return Data(b)
+	if (c.Trap|c.Excep)&^(Inexact|Underflow|Overflow|DivByZero|Invalid) != 0 {
+		return fmt.Errorf("invalid exceptions: %#x, %#x", c.Trap, c.Excep)
+	}
+	return nil
 }
 
 // Data is input or output from a test case.
@@ -136,14 +177,15 @@ const (
 )
 
 var exceptions = [...]struct {
-	e Exception
-	s string
+	e   Exception
+	s   string
+	val string // .fptest form
 }{
-	{Inexact, "Inexact"},
-	{Underflow, "Underflow"},
-	{Overflow, "Overflow"},
-	{DivByZero, "DivByZero"},
-	{Invalid, "Invalid"},
+	{Inexact, "Inexact", "x"},
+	{Underflow, "Underflow", "u"},
+	{Overflow, "Overflow", "o"},
+	{DivByZero, "DivByZero", "z"},
+	{Invalid, "Invalid", "i"},
 }
 
 func (e Exception) String() string {
@@ -160,6 +202,16 @@ func (e Exception) String() string {
 	return strings.TrimSuffix(res, " | ")
 }
 
+// appendVal appends e in .fptest form to b.
+func (e Exception) appendVal(b []byte) []byte {
+	for _, x := range exceptions {
+		if e&x.e != 0 {
+			b = append(b, x.val...)
+		}
+	}
+	return b
+}
+
 var valToException = map[string]Exception{
 	"x": Inexact,
 	"u": Underflow, // tininess and "extraordinary" error
@@ -224,10 +276,22 @@ const (
 	Equiv                 // equivalent
 )
 
+// opToVal and modeToVal are the inverses of valToOp and valToMode.
+var (
+	opToVal   = make(map[Op]string, len(valToOp))
+	modeToVal = make(map[big.RoundingMode]string, len(valToMode))
+)
+
 func init() {
 	if len(valToOp) != int(Equiv)+1 /* +1 since Add is 0 */ {
 		panic(fmt.Sprintf("wanted %d toks, got %d", Equiv, len(valToOp)))
 	}
+	for val, op := range valToOp {
+		opToVal[op] = val
+	}
+	for val, mode := range valToMode {
+		modeToVal[mode] = val
+	}
 }
 
 const maxOpLen = 6
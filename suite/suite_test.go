@@ -0,0 +1,97 @@
+package suite
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCase_String(t *testing.T) {
+	file, err := os.Open(filepath.Join("_testdata", "fptest.tar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		cases, err := ParseCases(tr)
+		if err != nil {
+			t.Fatalf("%s: %v", h.Name, err)
+		}
+		for i, c := range cases {
+			s := c.String()
+			c2, err := ParseCase([]byte(s))
+			if err != nil {
+				t.Fatalf("%s#%d: ParseCase(%q): %v", h.Name, i+1, s, err)
+			}
+			if !reflect.DeepEqual(c, c2) {
+				t.Fatalf("%s#%d: %q\nwanted: %#v\ngot   : %#v", h.Name, i+1, s, c, c2)
+			}
+		}
+	}
+}
+
+func TestWriteCases(t *testing.T) {
+	const in = `d64+ =0 i +1 +2 -> +3
+d64/ > +1 +3 -> +0.3333333333333334 x
+d128V 0 xo -1 -> Q i
+d32cp =^ -Inf -> -Inf
+`
+	cases, err := ParseCases(bytes.NewReader([]byte(in)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCases(&buf, cases); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != in {
+		t.Fatalf("wanted:\n%s\ngot:\n%s", in, buf.String())
+	}
+
+	for i, c := range [...]Case{
+		0: {Prefix: "x", Op: Add, Inputs: []Data{"1"}},
+		1: {Prefix: "d", Op: Equiv + 1, Inputs: []Data{"1"}},
+		2: {Prefix: "d", Op: Add, Mode: big.AwayFromZero, Inputs: []Data{"1"}},
+		3: {Prefix: "d", Op: Add},
+	} {
+		if err := WriteCases(&buf, []Case{c}); err == nil {
+			t.Fatalf("#%d: expected an error", i)
+		}
+	}
+
+	// Nothing is written if any case is invalid, even after a buffer's worth
+	// of valid cases.
+	bad := make([]Case, 0, 1000)
+	for len(bad) < cap(bad)-1 {
+		bad = append(bad, cases...)
+	}
+	bad = append(bad, Case{Prefix: "x", Op: Add, Inputs: []Data{"1"}})
+	buf.Reset()
+	if err := WriteCases(&buf, bad); err == nil {
+		t.Fatal("expected an error")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("wanted nothing written, got %d bytes", buf.Len())
+	}
+}
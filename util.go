@@ -11,7 +11,7 @@ import (
 const debug = true
 
 // cmpNorm compares x and y in the range [0.1, 0.999...] and returns true if x
-// > y.
+// >= y.
 func cmpNorm(x int64, xs int32, y int64, ys int32) (ok bool) {
 	goodx, goody := true, true
 	if diff := xs - ys; diff != 0 {
@@ -23,7 +23,7 @@ func cmpNorm(x int64, xs int32, y int64, ys int32) (ok bool) {
 	}
 	if goodx {
 		if goody {
-			return arith.AbsCmp(x, y) > 0
+			return arith.AbsCmp(x, y) >= 0
 		}
 		return false
 	}
@@ -31,14 +31,14 @@ func cmpNorm(x int64, xs int32, y int64, ys int32) (ok bool) {
 }
 
 // cmpNormBig compares x and y in the range [0.1, 0.999...] and returns true if
-// x > y.
+// x >= y.
 func cmpNormBig(x *big.Int, xs int32, y *big.Int, ys int32) (ok bool) {
 	if diff := xs - ys; diff < 0 {
 		x = checked.MulBigPow10(new(big.Int).Set(x), -diff)
 	} else {
 		y = checked.MulBigPow10(new(big.Int).Set(y), diff)
 	}
-	return arith.BigAbsCmp(x, y) > 0
+	return arith.BigAbsCmp(x, y) >= 0
 }
 
 // scalex adjusts x by scale. If scale < 0, x = x * 10^-scale, otherwise